@@ -0,0 +1,333 @@
+package category
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	svCtx "github.com/LeHNam/wao-api/context"
+	"github.com/LeHNam/wao-api/helpers/utils"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// treeSort lists parents before their children and siblings by name
+const treeSort = "depth,name"
+
+type CategoryServer struct {
+	sc *svCtx.ServiceContext
+}
+
+func NewCategoryServer(sc *svCtx.ServiceContext) *CategoryServer {
+	return &CategoryServer{
+		sc: sc,
+	}
+}
+
+// GetCategories handles the category tree API
+func (s *CategoryServer) GetCategories(ctx context.Context, request GetCategoriesRequestObject) (GetCategoriesResponseObject, error) {
+	sort := treeSort
+	categories, err := s.sc.CategoryRepo.Find(ctx, map[string]interface{}{}, []string{}, 0, 0, &sort)
+	if err != nil {
+		s.sc.Log.Error("failed to list categories", zap.Error(err))
+		return GetCategories500JSONResponse{
+			Message: utils.Stp("failed to get list of categories"),
+		}, nil
+	}
+
+	return GetCategories200JSONResponse{
+		Items: buildTree(categories, nil),
+	}, nil
+}
+
+// PostCategories handles the create category API
+func (s *CategoryServer) PostCategories(ctx context.Context, request PostCategoriesRequestObject) (PostCategoriesResponseObject, error) {
+	name := strings.TrimSpace(request.Body.Name)
+	if name == "" {
+		return PostCategories400JSONResponse{
+			Message: utils.Stp("name is required"),
+		}, nil
+	}
+
+	category := &models.Category{
+		ID:       uuid.New(),
+		ParentID: request.Body.ParentId,
+		Name:     name,
+	}
+	parentPath := ""
+	if category.ParentID != nil {
+		parent, err := s.sc.CategoryRepo.First(ctx, *category.ParentID)
+		if err != nil {
+			return PostCategories400JSONResponse{
+				Message: utils.Stp("parent category not found"),
+			}, nil
+		}
+		parentPath = parent.Path
+		category.Depth = parent.Depth + 1
+	}
+	category.Path = models.CategoryPath(parentPath, category.ID)
+
+	exists, err := s.siblingExists(ctx, s.sc.DB, category.ParentID, name, uuid.Nil)
+	if err != nil {
+		s.sc.Log.Error("failed to check category name", zap.Error(err))
+		return PostCategories400JSONResponse{
+			Message: utils.Stp("create category failed"),
+		}, nil
+	}
+	if exists {
+		return PostCategories409JSONResponse{
+			Message: utils.Stp("a category with this name already exists"),
+		}, nil
+	}
+
+	if err := s.sc.CategoryRepo.Create(ctx, category); err != nil {
+		s.sc.Log.Error("failed to create category", zap.Error(err))
+		return PostCategories400JSONResponse{
+			Message: utils.Stp("create category failed"),
+		}, nil
+	}
+
+	return PostCategories201JSONResponse(toCategoryResponse(category)), nil
+}
+
+// GetCategoriesId handles the category detail API, the category is returned with its subtree
+func (s *CategoryServer) GetCategoriesId(ctx context.Context, request GetCategoriesIdRequestObject) (GetCategoriesIdResponseObject, error) {
+	category, err := s.sc.CategoryRepo.First(ctx, request.Id)
+	if err != nil {
+		return GetCategoriesId404JSONResponse{
+			Message: utils.Stp("category not found"),
+		}, nil
+	}
+
+	sort := treeSort
+	descendants, err := s.sc.CategoryRepo.Find(ctx, map[string]interface{}{
+		"path" + database.CONDITION_PREFIX:  category.Path,
+		"id" + database.CONDITION_NOT_EQUAL: category.ID,
+	}, []string{}, 0, 0, &sort)
+	if err != nil {
+		s.sc.Log.Error("failed to load subcategories", zap.Error(err))
+		return GetCategoriesId404JSONResponse{
+			Message: utils.Stp("failed to get category"),
+		}, nil
+	}
+
+	node := toCategoryNode(category)
+	node.Children = buildTree(descendants, &category.ID)
+	return GetCategoriesId200JSONResponse(node), nil
+}
+
+// PutCategoriesId handles the update category API. Moving a category rewrites the path of its whole subtree.
+func (s *CategoryServer) PutCategoriesId(ctx context.Context, request PutCategoriesIdRequestObject) (PutCategoriesIdResponseObject, error) {
+	name := strings.TrimSpace(request.Body.Name)
+	if name == "" {
+		return PutCategoriesId400JSONResponse{
+			Message: utils.Stp("name is required"),
+		}, nil
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PutCategoriesId400JSONResponse{
+			Message: utils.Stp("Create DB transaction failed"),
+		}, nil
+	}
+	defer tx.Rollback()
+
+	category, err := s.sc.CategoryRepo.WithTx(tx).FirstForUpdate(ctx, request.Id)
+	if err != nil {
+		return PutCategoriesId404JSONResponse{
+			Message: utils.Stp("category not found"),
+		}, nil
+	}
+
+	parentPath := ""
+	depth := 0
+	if parentID := request.Body.ParentId; parentID != nil {
+		parent, err := s.sc.CategoryRepo.WithTx(tx).First(ctx, *parentID)
+		if err != nil {
+			return PutCategoriesId400JSONResponse{
+				Message: utils.Stp("parent category not found"),
+			}, nil
+		}
+		if strings.HasPrefix(parent.Path, category.Path) {
+			return PutCategoriesId400JSONResponse{
+				Message: utils.Stp("a category can not be moved below itself"),
+			}, nil
+		}
+		parentPath = parent.Path
+		depth = parent.Depth + 1
+	}
+
+	exists, err := s.siblingExists(ctx, tx, request.Body.ParentId, name, category.ID)
+	if err != nil {
+		s.sc.Log.Error("failed to check category name", zap.Error(err))
+		return PutCategoriesId400JSONResponse{
+			Message: utils.Stp("update category failed"),
+		}, nil
+	}
+	if exists {
+		return PutCategoriesId409JSONResponse{
+			Message: utils.Stp("a category with this name already exists"),
+		}, nil
+	}
+
+	now := time.Now()
+	path := models.CategoryPath(parentPath, category.ID)
+	if path != category.Path {
+		// the category itself is part of its subtree, its remaining path is empty
+		err = s.sc.CategoryRepo.WithTx(tx).GetDB().WithContext(ctx).Exec(
+			"UPDATE categories SET path = ? || substr(path, ?), depth = depth + ?, updated_at = ? WHERE path LIKE ? AND deleted_at IS NULL",
+			path, len(category.Path)+1, depth-category.Depth, now, category.Path+"%",
+		).Error
+		if err != nil {
+			s.sc.Log.Error("failed to move category", zap.Error(err))
+			return PutCategoriesId400JSONResponse{
+				Message: utils.Stp("update category failed"),
+			}, nil
+		}
+	}
+
+	err = s.sc.CategoryRepo.WithTx(tx).Update(ctx, category.ID, map[string]interface{}{
+		"name":       name,
+		"parent_id":  request.Body.ParentId,
+		"updated_at": now,
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to update category", zap.Error(err))
+		return PutCategoriesId400JSONResponse{
+			Message: utils.Stp("update category failed"),
+		}, nil
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit category", zap.Error(err))
+		return PutCategoriesId400JSONResponse{
+			Message: utils.Stp("update category failed"),
+		}, nil
+	}
+
+	category.Name = name
+	category.ParentID = request.Body.ParentId
+	category.Path = path
+	category.Depth = depth
+	category.UpdatedAt = now
+	return PutCategoriesId200JSONResponse(toCategoryResponse(category)), nil
+}
+
+// DeleteCategoriesId handles the delete category API
+func (s *CategoryServer) DeleteCategoriesId(ctx context.Context, request DeleteCategoriesIdRequestObject) (DeleteCategoriesIdResponseObject, error) {
+	category, err := s.sc.CategoryRepo.First(ctx, request.Id)
+	if err != nil {
+		return DeleteCategoriesId404JSONResponse{
+			Message: utils.Stp("category not found"),
+		}, nil
+	}
+
+	children, err := s.sc.CategoryRepo.Count(ctx, map[string]interface{}{"parent_id": category.ID})
+	if err != nil {
+		s.sc.Log.Error("failed to count subcategories", zap.Error(err))
+		return DeleteCategoriesId404JSONResponse{
+			Message: utils.Stp("delete category failed"),
+		}, nil
+	}
+	if children > 0 {
+		return DeleteCategoriesId409JSONResponse{
+			Message: utils.Stp("the category still has subcategories"),
+		}, nil
+	}
+
+	products, err := s.sc.ProductRepo.Count(ctx, map[string]interface{}{
+		"category_id":                          category.ID,
+		"deleted_at" + database.CONDITION_NULL: nil,
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to count category products", zap.Error(err))
+		return DeleteCategoriesId404JSONResponse{
+			Message: utils.Stp("delete category failed"),
+		}, nil
+	}
+	if products > 0 {
+		return DeleteCategoriesId409JSONResponse{
+			Message: utils.Stp("the category still has products"),
+		}, nil
+	}
+
+	if err := s.sc.CategoryRepo.Delete(ctx, category.ID); err != nil {
+		s.sc.Log.Error("failed to delete category", zap.Error(err))
+		return DeleteCategoriesId404JSONResponse{
+			Message: utils.Stp("delete category failed"),
+		}, nil
+	}
+
+	return DeleteCategoriesId204Response{}, nil
+}
+
+// siblingExists reports whether the parent already has another category with the given name
+func (s *CategoryServer) siblingExists(ctx context.Context, db *gorm.DB, parentID *uuid.UUID, name string, exclude uuid.UUID) (bool, error) {
+	cond := map[string]interface{}{
+		"LOWER(name)":                       strings.ToLower(name),
+		"id" + database.CONDITION_NOT_EQUAL: exclude,
+	}
+	if parentID != nil {
+		cond["parent_id"] = *parentID
+	} else {
+		cond["parent_id"+database.CONDITION_NULL] = nil
+	}
+	count, err := s.sc.CategoryRepo.WithTx(db).Count(ctx, cond)
+	return count > 0, err
+}
+
+// buildTree nests categories sorted parents first below the given parent, nil for the roots
+func buildTree(categories []models.Category, parentID *uuid.UUID) []CategoryNode {
+	children := make(map[uuid.UUID][]*models.Category, len(categories))
+	var roots []*models.Category
+	for i := range categories {
+		c := &categories[i]
+		if c.ParentID == nil || (parentID != nil && *c.ParentID == *parentID) {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], c)
+	}
+
+	var nest func(nodes []*models.Category) []CategoryNode
+	nest = func(nodes []*models.Category) []CategoryNode {
+		items := make([]CategoryNode, 0, len(nodes))
+		for _, c := range nodes {
+			node := toCategoryNode(c)
+			node.Children = nest(children[c.ID])
+			items = append(items, node)
+		}
+		return items
+	}
+	return nest(roots)
+}
+
+func toCategoryResponse(c *models.Category) Category {
+	return Category{
+		Id:        c.ID,
+		ParentId:  c.ParentID,
+		Name:      c.Name,
+		Path:      c.Path,
+		Depth:     c.Depth,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func toCategoryNode(c *models.Category) CategoryNode {
+	return CategoryNode{
+		Id:        c.ID,
+		ParentId:  c.ParentID,
+		Name:      c.Name,
+		Path:      c.Path,
+		Depth:     c.Depth,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+		Children:  []CategoryNode{},
+	}
+}
@@ -0,0 +1,688 @@
+// Package category provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package category
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Category defines model for Category.
+type Category struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Depth 0 for root categories
+	Depth    int                 `json:"depth"`
+	Id       openapi_types.UUID  `json:"id"`
+	Name     string              `json:"name"`
+	ParentId *openapi_types.UUID `json:"parent_id,omitempty"`
+
+	// Path Ids from the root down to the category, e.g. /<root id>/<id>/
+	Path      string    `json:"path"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CategoryNode defines model for CategoryNode.
+type CategoryNode struct {
+	Children  []CategoryNode `json:"children"`
+	CreatedAt time.Time      `json:"created_at"`
+
+	// Depth 0 for root categories
+	Depth    int                 `json:"depth"`
+	Id       openapi_types.UUID  `json:"id"`
+	Name     string              `json:"name"`
+	ParentId *openapi_types.UUID `json:"parent_id,omitempty"`
+
+	// Path Ids from the root down to the category, e.g. /<root id>/<id>/
+	Path      string    `json:"path"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CategoryRequest defines model for CategoryRequest.
+type CategoryRequest struct {
+	Name     string              `json:"name"`
+	ParentId *openapi_types.UUID `json:"parent_id,omitempty"`
+}
+
+// CategoryTree defines model for CategoryTree.
+type CategoryTree struct {
+	Items []CategoryNode `json:"items"`
+}
+
+// MessageResponse defines model for MessageResponse.
+type MessageResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// PostCategoriesJSONRequestBody defines body for PostCategories for application/json ContentType.
+type PostCategoriesJSONRequestBody = CategoryRequest
+
+// PutCategoriesIdJSONRequestBody defines body for PutCategoriesId for application/json ContentType.
+type PutCategoriesIdJSONRequestBody = CategoryRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get the category tree
+	// (GET /categories)
+	GetCategories(c *gin.Context)
+	// Create a category
+	// (POST /categories)
+	PostCategories(c *gin.Context)
+	// Delete a category
+	// (DELETE /categories/{id})
+	DeleteCategoriesId(c *gin.Context, id openapi_types.UUID)
+	// Get a category with its descendants
+	// (GET /categories/{id})
+	GetCategoriesId(c *gin.Context, id openapi_types.UUID)
+	// Rename or move a category
+	// (PUT /categories/{id})
+	PutCategoriesId(c *gin.Context, id openapi_types.UUID)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// GetCategories operation middleware
+func (siw *ServerInterfaceWrapper) GetCategories(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCategories(c)
+}
+
+// PostCategories operation middleware
+func (siw *ServerInterfaceWrapper) PostCategories(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostCategories(c)
+}
+
+// DeleteCategoriesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCategoriesId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteCategoriesId(c, id)
+}
+
+// GetCategoriesId operation middleware
+func (siw *ServerInterfaceWrapper) GetCategoriesId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCategoriesId(c, id)
+}
+
+// PutCategoriesId operation middleware
+func (siw *ServerInterfaceWrapper) PutCategoriesId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutCategoriesId(c, id)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/categories", wrapper.GetCategories)
+	router.POST(options.BaseURL+"/categories", wrapper.PostCategories)
+	router.DELETE(options.BaseURL+"/categories/:id", wrapper.DeleteCategoriesId)
+	router.GET(options.BaseURL+"/categories/:id", wrapper.GetCategoriesId)
+	router.PUT(options.BaseURL+"/categories/:id", wrapper.PutCategoriesId)
+}
+
+type GetCategoriesRequestObject struct {
+}
+
+type GetCategoriesResponseObject interface {
+	VisitGetCategoriesResponse(w http.ResponseWriter) error
+}
+
+type GetCategories200JSONResponse CategoryTree
+
+func (response GetCategories200JSONResponse) VisitGetCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCategories500JSONResponse MessageResponse
+
+func (response GetCategories500JSONResponse) VisitGetCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostCategoriesRequestObject struct {
+	Body *PostCategoriesJSONRequestBody
+}
+
+type PostCategoriesResponseObject interface {
+	VisitPostCategoriesResponse(w http.ResponseWriter) error
+}
+
+type PostCategories201JSONResponse Category
+
+func (response PostCategories201JSONResponse) VisitPostCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostCategories400JSONResponse MessageResponse
+
+func (response PostCategories400JSONResponse) VisitPostCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostCategories409JSONResponse MessageResponse
+
+func (response PostCategories409JSONResponse) VisitPostCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCategoriesIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteCategoriesIdResponseObject interface {
+	VisitDeleteCategoriesIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteCategoriesId204Response struct {
+}
+
+func (response DeleteCategoriesId204Response) VisitDeleteCategoriesIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteCategoriesId404JSONResponse MessageResponse
+
+func (response DeleteCategoriesId404JSONResponse) VisitDeleteCategoriesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCategoriesId409JSONResponse MessageResponse
+
+func (response DeleteCategoriesId409JSONResponse) VisitDeleteCategoriesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCategoriesIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetCategoriesIdResponseObject interface {
+	VisitGetCategoriesIdResponse(w http.ResponseWriter) error
+}
+
+type GetCategoriesId200JSONResponse CategoryNode
+
+func (response GetCategoriesId200JSONResponse) VisitGetCategoriesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCategoriesId404JSONResponse MessageResponse
+
+func (response GetCategoriesId404JSONResponse) VisitGetCategoriesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutCategoriesIdRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *PutCategoriesIdJSONRequestBody
+}
+
+type PutCategoriesIdResponseObject interface {
+	VisitPutCategoriesIdResponse(w http.ResponseWriter) error
+}
+
+type PutCategoriesId200JSONResponse Category
+
+func (response PutCategoriesId200JSONResponse) VisitPutCategoriesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutCategoriesId400JSONResponse MessageResponse
+
+func (response PutCategoriesId400JSONResponse) VisitPutCategoriesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutCategoriesId404JSONResponse MessageResponse
+
+func (response PutCategoriesId404JSONResponse) VisitPutCategoriesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutCategoriesId409JSONResponse MessageResponse
+
+func (response PutCategoriesId409JSONResponse) VisitPutCategoriesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get the category tree
+	// (GET /categories)
+	GetCategories(ctx context.Context, request GetCategoriesRequestObject) (GetCategoriesResponseObject, error)
+	// Create a category
+	// (POST /categories)
+	PostCategories(ctx context.Context, request PostCategoriesRequestObject) (PostCategoriesResponseObject, error)
+	// Delete a category
+	// (DELETE /categories/{id})
+	DeleteCategoriesId(ctx context.Context, request DeleteCategoriesIdRequestObject) (DeleteCategoriesIdResponseObject, error)
+	// Get a category with its descendants
+	// (GET /categories/{id})
+	GetCategoriesId(ctx context.Context, request GetCategoriesIdRequestObject) (GetCategoriesIdResponseObject, error)
+	// Rename or move a category
+	// (PUT /categories/{id})
+	PutCategoriesId(ctx context.Context, request PutCategoriesIdRequestObject) (PutCategoriesIdResponseObject, error)
+}
+
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetCategories operation middleware
+func (sh *strictHandler) GetCategories(ctx *gin.Context) {
+	var request GetCategoriesRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCategories(ctx, request.(GetCategoriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCategories")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCategoriesResponseObject); ok {
+		if err := validResponse.VisitGetCategoriesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostCategories operation middleware
+func (sh *strictHandler) PostCategories(ctx *gin.Context) {
+	var request PostCategoriesRequestObject
+
+	var body PostCategoriesJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostCategories(ctx, request.(PostCategoriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostCategories")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostCategoriesResponseObject); ok {
+		if err := validResponse.VisitPostCategoriesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteCategoriesId operation middleware
+func (sh *strictHandler) DeleteCategoriesId(ctx *gin.Context, id openapi_types.UUID) {
+	var request DeleteCategoriesIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCategoriesId(ctx, request.(DeleteCategoriesIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCategoriesId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteCategoriesIdResponseObject); ok {
+		if err := validResponse.VisitDeleteCategoriesIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCategoriesId operation middleware
+func (sh *strictHandler) GetCategoriesId(ctx *gin.Context, id openapi_types.UUID) {
+	var request GetCategoriesIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCategoriesId(ctx, request.(GetCategoriesIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCategoriesId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetCategoriesIdResponseObject); ok {
+		if err := validResponse.VisitGetCategoriesIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutCategoriesId operation middleware
+func (sh *strictHandler) PutCategoriesId(ctx *gin.Context, id openapi_types.UUID) {
+	var request PutCategoriesIdRequestObject
+
+	request.Id = id
+
+	var body PutCategoriesIdJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutCategoriesId(ctx, request.(PutCategoriesIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutCategoriesId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutCategoriesIdResponseObject); ok {
+		if err := validResponse.VisitPutCategoriesIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+RXUW/bNhD+KwS3R9V2m+RhfkszbMiwrkEWYA+ZUTDi2WIhkerxlFQw9N+HI2VZsow2",
+	"XrwkwJ4sieTd8bvvvjuvZeqK0lmw5OV8LX2aQaHC44UiWDms+blEVwKSgbCSIigC/UkRvy0dFvwktSJ4",
+	"Q6YAmUiqS5Bz6QmNXckmkRpKyni7Bp+iKck4K+dyJpYOBTpHIo3+2Ed33liCFSAbMHrgrKqM3ufHqgJ4",
+	"42ihVAiWPj3STKn2RXupvViiKwRlEIPW7sEKcuFDe4E6ETBZTcT072o2O0nDNqPDC7Tfutd9rqtSH4hu",
+	"k0iEL5VB0HJ+K8ONAg7tPTboJ/3MDRwtOpvu7jOkxHFs8v+H0wFRlecfl3J+u5Y/IizlXP4w3XJn2hJn",
+	"ujklm2REm8zkGsHysyEowsfH2AoRNF2IClHVo1t31sd3WfRucw1fKvA0JvWGOIX6+jvYFaf/3dlZIgtj",
+	"N+9vk6fRaifi4PFbyN8gwDjQDrrjYxgt7gvpA3ivVnANvnTW74mqiBv2lF4zMtck0kNaoaH6Tw4zWrgD",
+	"hYDnFWXbt182gP72141Mojqxpbi6RTgjKmXDho1dunHhXqHTVdppTC2IoU0kGcr5/AYlcX51KRN5D+jj",
+	"wbeT2WTGALgSrCqNnMuTyWxy0pZWCHzaU675Wq4gsIvBUez+Usu5/BXooq9v2AIZTrybzfgndZbAhsOq",
+	"LHOThuPTz97ZrTQ/Nt+BOwGRIRLXQ6kVD4YyVi+DgjeC1cqS5xufHTGqXfrsCezSEqBVufCA94ACEB0O",
+	"uBK0p8+S20WzSKSvikJhHUEeCHGXZbXyQSLa78zwr29KwMJ4zvNgcYKgtGTJKJ3fk8kr53dTGRTlvdP1",
+	"0bO4UatmWKmEFTQjEr09uvt9eepKpe0lTJXT56XKe6UFbpBh7z89p/ebDERUfaFyJkstMuWF2tKurSnj",
+	"RZD4gzh8EVDtWTuYvzEvcsFue9I0XRvdRGXMgWCskR9tXu8qg6tI+Oqu91VZLcqopl6kyoo7ENGinshk",
+	"p1Z+DgvbarnUQTdRFUCAPoBh2Hc7psQeHAeYIduTXv6+12UXo8o4Hd+2o3EbfCTS6XMSqQvBOhJLV1n9",
+	"QmzuiOvJ5Hlg8zDpDrucH8bmSICnsLllK+vx9xvrS/Hr+O07jmvf4IwGUiZ/Jaw9tEnvaqUhP5g+/n3L",
+	"rmhc6h/cvbGrvtPC3YMPXh8ylwOznRBgIs6HgbH8dQO+MCzy/b+q9VjxrqqXoeMrGUFmzzuCtP9gX8MI",
+	"8v/sHP/dHHQNfIY7DxfrUzpIJAnPQ03zzwA3zyfC7xIAAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package exchange_rate
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	svCtx "github.com/LeHNam/wao-api/context"
+	"github.com/LeHNam/wao-api/helpers/utils"
+	"github.com/LeHNam/wao-api/models"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+)
+
+type ExchangeRateServer struct {
+	sc *svCtx.ServiceContext
+}
+
+func NewExchangeRateServer(sc *svCtx.ServiceContext) *ExchangeRateServer {
+	return &ExchangeRateServer{
+		sc: sc,
+	}
+}
+
+// GetExchangeRates handles the list exchange rates API
+func (s *ExchangeRateServer) GetExchangeRates(ctx context.Context, request GetExchangeRatesRequestObject) (GetExchangeRatesResponseObject, error) {
+	sort := "currency"
+	rates, err := s.sc.ExchangeRateRepo.Find(ctx, map[string]interface{}{}, []string{}, 0, 0, &sort)
+	if err != nil {
+		s.sc.Log.Error("failed to list exchange rates", zap.Error(err))
+		return GetExchangeRates500JSONResponse{
+			Message: utils.Stp("failed to get list of exchange rates"),
+		}, nil
+	}
+
+	items := make([]ExchangeRate, 0, len(rates))
+	for i := range rates {
+		items = append(items, toExchangeRateResponse(&rates[i]))
+	}
+	return GetExchangeRates200JSONResponse{
+		BaseCurrency: s.baseCurrency(),
+		Rates:        items,
+	}, nil
+}
+
+// PutExchangeRatesCurrency handles the set exchange rate API
+func (s *ExchangeRateServer) PutExchangeRatesCurrency(ctx context.Context, request PutExchangeRatesCurrencyRequestObject) (PutExchangeRatesCurrencyResponseObject, error) {
+	currency := strings.ToUpper(request.Currency)
+	if currency == s.baseCurrency() {
+		return PutExchangeRatesCurrency400JSONResponse{
+			Message: utils.Stp("the rate of the base currency is always 1"),
+		}, nil
+	}
+	if !request.Body.Rate.IsPositive() {
+		return PutExchangeRatesCurrency400JSONResponse{
+			Message: utils.Stp("rate must be greater than zero"),
+		}, nil
+	}
+
+	userCtx := utils.GetUserFromContext(ctx)
+	rate := &models.ExchangeRate{
+		Currency:  currency,
+		Rate:      request.Body.Rate.Round(8),
+		UpdatedAt: time.Now(),
+		UpdatedBy: userCtx.ID,
+	}
+	err := s.sc.ExchangeRateRepo.GetDB().WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at", "updated_by"}),
+	}).Create(rate).Error
+	if err != nil {
+		s.sc.Log.Error("failed to save exchange rate", zap.Error(err))
+		return PutExchangeRatesCurrency400JSONResponse{
+			Message: utils.Stp("save exchange rate failed"),
+		}, nil
+	}
+
+	return PutExchangeRatesCurrency200JSONResponse(toExchangeRateResponse(rate)), nil
+}
+
+// DeleteExchangeRatesCurrency handles the delete exchange rate API
+func (s *ExchangeRateServer) DeleteExchangeRatesCurrency(ctx context.Context, request DeleteExchangeRatesCurrencyRequestObject) (DeleteExchangeRatesCurrencyResponseObject, error) {
+	cond := map[string]interface{}{
+		"currency": strings.ToUpper(request.Currency),
+	}
+	count, err := s.sc.ExchangeRateRepo.Count(ctx, cond)
+	if err != nil || count == 0 {
+		return DeleteExchangeRatesCurrency404JSONResponse{
+			Message: utils.Stp("exchange rate not found"),
+		}, nil
+	}
+
+	if err := s.sc.ExchangeRateRepo.DeleteWhere(ctx, cond); err != nil {
+		s.sc.Log.Error("failed to delete exchange rate", zap.Error(err))
+		return DeleteExchangeRatesCurrency404JSONResponse{
+			Message: utils.Stp("delete exchange rate failed"),
+		}, nil
+	}
+
+	return DeleteExchangeRatesCurrency204Response{}, nil
+}
+
+func (s *ExchangeRateServer) baseCurrency() string {
+	return strings.ToUpper(strings.TrimSpace(s.sc.Config.Currency.Base))
+}
+
+func toExchangeRateResponse(rate *models.ExchangeRate) ExchangeRate {
+	return ExchangeRate{
+		Currency:  rate.Currency,
+		Rate:      rate.Rate,
+		UpdatedAt: rate.UpdatedAt,
+		UpdatedBy: &rate.UpdatedBy,
+	}
+}
@@ -0,0 +1,466 @@
+// Package exchange_rate provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package exchange_rate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// CurrencyCode ISO 4217 currency code
+type CurrencyCode = string
+
+// ExchangeRate defines model for ExchangeRate.
+type ExchangeRate struct {
+	Currency string `json:"currency"`
+
+	// Rate Units of the currency per unit of the base currency
+	Rate      decimal.Decimal     `json:"rate"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	UpdatedBy *openapi_types.UUID `json:"updated_by,omitempty"`
+}
+
+// ExchangeRateList defines model for ExchangeRateList.
+type ExchangeRateList struct {
+	// BaseCurrency Currency product prices are in, its rate is always 1
+	BaseCurrency string         `json:"base_currency"`
+	Rates        []ExchangeRate `json:"rates"`
+}
+
+// ExchangeRateRequest defines model for ExchangeRateRequest.
+type ExchangeRateRequest struct {
+	// Rate Units of the currency per unit of the base currency
+	Rate decimal.Decimal `json:"rate"`
+}
+
+// MessageResponse defines model for MessageResponse.
+type MessageResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// PutExchangeRatesCurrencyJSONRequestBody defines body for PutExchangeRatesCurrency for application/json ContentType.
+type PutExchangeRatesCurrencyJSONRequestBody = ExchangeRateRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List exchange rates
+	// (GET /exchange-rates)
+	GetExchangeRates(c *gin.Context)
+	// Delete an exchange rate
+	// (DELETE /exchange-rates/{currency})
+	DeleteExchangeRatesCurrency(c *gin.Context, currency CurrencyCode)
+	// Set an exchange rate
+	// (PUT /exchange-rates/{currency})
+	PutExchangeRatesCurrency(c *gin.Context, currency CurrencyCode)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// GetExchangeRates operation middleware
+func (siw *ServerInterfaceWrapper) GetExchangeRates(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetExchangeRates(c)
+}
+
+// DeleteExchangeRatesCurrency operation middleware
+func (siw *ServerInterfaceWrapper) DeleteExchangeRatesCurrency(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "currency" -------------
+	var currency CurrencyCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "currency", c.Param("currency"), &currency, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter currency: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteExchangeRatesCurrency(c, currency)
+}
+
+// PutExchangeRatesCurrency operation middleware
+func (siw *ServerInterfaceWrapper) PutExchangeRatesCurrency(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "currency" -------------
+	var currency CurrencyCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "currency", c.Param("currency"), &currency, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter currency: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutExchangeRatesCurrency(c, currency)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/exchange-rates", wrapper.GetExchangeRates)
+	router.DELETE(options.BaseURL+"/exchange-rates/:currency", wrapper.DeleteExchangeRatesCurrency)
+	router.PUT(options.BaseURL+"/exchange-rates/:currency", wrapper.PutExchangeRatesCurrency)
+}
+
+type GetExchangeRatesRequestObject struct {
+}
+
+type GetExchangeRatesResponseObject interface {
+	VisitGetExchangeRatesResponse(w http.ResponseWriter) error
+}
+
+type GetExchangeRates200JSONResponse ExchangeRateList
+
+func (response GetExchangeRates200JSONResponse) VisitGetExchangeRatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExchangeRates500JSONResponse MessageResponse
+
+func (response GetExchangeRates500JSONResponse) VisitGetExchangeRatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteExchangeRatesCurrencyRequestObject struct {
+	Currency CurrencyCode `json:"currency"`
+}
+
+type DeleteExchangeRatesCurrencyResponseObject interface {
+	VisitDeleteExchangeRatesCurrencyResponse(w http.ResponseWriter) error
+}
+
+type DeleteExchangeRatesCurrency204Response struct {
+}
+
+func (response DeleteExchangeRatesCurrency204Response) VisitDeleteExchangeRatesCurrencyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteExchangeRatesCurrency404JSONResponse MessageResponse
+
+func (response DeleteExchangeRatesCurrency404JSONResponse) VisitDeleteExchangeRatesCurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutExchangeRatesCurrencyRequestObject struct {
+	Currency CurrencyCode `json:"currency"`
+	Body     *PutExchangeRatesCurrencyJSONRequestBody
+}
+
+type PutExchangeRatesCurrencyResponseObject interface {
+	VisitPutExchangeRatesCurrencyResponse(w http.ResponseWriter) error
+}
+
+type PutExchangeRatesCurrency200JSONResponse ExchangeRate
+
+func (response PutExchangeRatesCurrency200JSONResponse) VisitPutExchangeRatesCurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutExchangeRatesCurrency400JSONResponse MessageResponse
+
+func (response PutExchangeRatesCurrency400JSONResponse) VisitPutExchangeRatesCurrencyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List exchange rates
+	// (GET /exchange-rates)
+	GetExchangeRates(ctx context.Context, request GetExchangeRatesRequestObject) (GetExchangeRatesResponseObject, error)
+	// Delete an exchange rate
+	// (DELETE /exchange-rates/{currency})
+	DeleteExchangeRatesCurrency(ctx context.Context, request DeleteExchangeRatesCurrencyRequestObject) (DeleteExchangeRatesCurrencyResponseObject, error)
+	// Set an exchange rate
+	// (PUT /exchange-rates/{currency})
+	PutExchangeRatesCurrency(ctx context.Context, request PutExchangeRatesCurrencyRequestObject) (PutExchangeRatesCurrencyResponseObject, error)
+}
+
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetExchangeRates operation middleware
+func (sh *strictHandler) GetExchangeRates(ctx *gin.Context) {
+	var request GetExchangeRatesRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetExchangeRates(ctx, request.(GetExchangeRatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetExchangeRates")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetExchangeRatesResponseObject); ok {
+		if err := validResponse.VisitGetExchangeRatesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteExchangeRatesCurrency operation middleware
+func (sh *strictHandler) DeleteExchangeRatesCurrency(ctx *gin.Context, currency CurrencyCode) {
+	var request DeleteExchangeRatesCurrencyRequestObject
+
+	request.Currency = currency
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteExchangeRatesCurrency(ctx, request.(DeleteExchangeRatesCurrencyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteExchangeRatesCurrency")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteExchangeRatesCurrencyResponseObject); ok {
+		if err := validResponse.VisitDeleteExchangeRatesCurrencyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutExchangeRatesCurrency operation middleware
+func (sh *strictHandler) PutExchangeRatesCurrency(ctx *gin.Context, currency CurrencyCode) {
+	var request PutExchangeRatesCurrencyRequestObject
+
+	request.Currency = currency
+
+	var body PutExchangeRatesCurrencyJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutExchangeRatesCurrency(ctx, request.(PutExchangeRatesCurrencyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutExchangeRatesCurrency")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutExchangeRatesCurrencyResponseObject); ok {
+		if err := validResponse.VisitPutExchangeRatesCurrencyResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/8yW32/jNgzH/xWBu0fnR68dBvit196GDOvu0O4wYEVWKDYT62ZLOopq4xX+3wfJcRIn",
+	"3rpiXXFPsS1KJL/8kMojZKayRqNmB+kjuKzASsbHC0+EOqsvTI7hPUeXkbKsjIYUZjcfxNnbk+9EtjET",
+	"WbBLANeysiVCCu8/XUMCVjIjhS2/356PfpOjP+ePp80bSIBrG8wck9IraBJ4v84KqVd4LTl6tGQsEiuM",
+	"8XSOwvPRVpI8EOQnrdgJsxRc4C5QiyS8VtwtLKTbre7i0r5aIEEC69HKjDYfc8xUJcvxZfu7vzpSlTXE",
+	"MXDJBaSwUlz4xTgz1cQVxjobop1sjoCmScDbXDLmdzJuWxqqwhOEjyNWFQ6p1O1Z1L093qv82DxIg1+8",
+	"IswhvYW9LKNivQjm291m8RkzPizJT8rxcVmCenf7temX4GKrOpncZywsqQydkIRC6USEAoVQhHJClg+y",
+	"duJkKOtgE/0pxio+vCFcQgrfTHYETzb4TnokNdvjJJGsj0TpZ9C5ekqNa/zicUiQl0QR11npnbrHK6VV",
+	"5StImTwmUHWv01fE9UC2mOeQSlfonFzhNTprtBvo5Ko1GGjk5ui4JgGHmSfF9U0o7gY6lIR07rnYvX3f",
+	"dcKPv/4CSTvJwknt6o6pgtm22Si9NMeV6oocsXRCrqTSjo/LkwjvMBdsWqSF9ZQVwcBQjuSCQ8Ul7p8Y",
+	"uBHnH2eQwD2Sa/2djKfjadDNWNTSKkjhdDwdn7azs4j5TnBzxGjbByuMtQu6yhD5LIcUfkDeZzQEQZsy",
+	"xE1vp9PwkxnNqON+aW2psnjC5LMzencJPKfH4miImg51PwvsaRqS/fYFIzkEbiCQmWYkLUvhkO6RBBIZ",
+	"6tEF6W2fq9t5M0/A+aqSVEMKIcfDRBJguXKhG3oFCm2xHlmkSrlQ5Z7FXbAYE8oc5iGAg9pOHjvAmpbN",
+	"EofmyYcImVBacKHcbqJIQkEYegdz4TWrUkih8WE7ZB3yGJIDbi6jlx46F7spZCXJChnJRZFU8B9nRgJa",
+	"xh7bn57bEdGOqn9Xwt6fjaaZH3F79kSjilaoPLB1Nj17Tbb6cWjDYmm8zp9HV1sBIXUfsX8mjEyJce38",
+	"8mr2M8ybBKznv4VFloG6WmSE4c4XfyDaONdi4FxgLR6QsJ1nuXhQXByj8tHzV8ZJvIffmbz+X0Zbd88f",
+	"3H8h6OaVpuvT0Dl536H/qmP1ncwFbQV6Bu43yP+V9aZp/hoA94qGY74MAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
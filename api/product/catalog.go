@@ -0,0 +1,89 @@
+package product
+
+import (
+	"context"
+
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// resolveTags returns the tags with the given names, the ones that do not exist yet are created
+func (s *ProductServer) resolveTags(ctx context.Context, tx *gorm.DB, names []string) ([]models.Tag, error) {
+	unique := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = models.NormalizeTag(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		unique = append(unique, name)
+	}
+	if len(unique) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	tags := make([]models.Tag, 0, len(unique))
+	for _, name := range unique {
+		tags = append(tags, models.Tag{ID: uuid.New(), Name: name})
+	}
+	err := s.sc.TagRepo.WithTx(tx).GetDB().WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).Create(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sc.TagRepo.WithTx(tx).Find(ctx, map[string]any{
+		"name" + database.CONDITION_IN: unique,
+	}, []string{}, 0, 0, nil)
+}
+
+// taggedProducts selects the ids of the products with the given tag, to be used as a subquery
+func (s *ProductServer) taggedProducts(tag string) *gorm.DB {
+	return s.sc.TagRepo.GetDB().
+		Table("product_tags").
+		Select("product_tags.product_id").
+		Joins("JOIN tags ON tags.id = product_tags.tag_id").
+		Where("tags.name = ?", models.NormalizeTag(tag))
+}
+
+// categoryTree returns the ids of a category and all of its descendants
+func (s *ProductServer) categoryTree(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error) {
+	category, err := s.sc.CategoryRepo.First(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.sc.CategoryRepo.Find(ctx, map[string]any{
+		"path" + database.CONDITION_PREFIX: category.Path,
+	}, []string{"id"}, 0, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(categories))
+	for _, c := range categories {
+		ids = append(ids, c.ID)
+	}
+	return ids, nil
+}
+
+func tagNames(tags []models.Tag) *[]string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return &names
+}
+
+func categoryID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	value := id.String()
+	return &value
+}
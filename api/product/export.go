@@ -0,0 +1,237 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// exportBatchSize is the number of products read from the database at a time
+const exportBatchSize = 500
+
+// exportSheet is the name of the sheet of an XLSX export
+const exportSheet = "Products"
+
+// catalogWriter writes the rows of an export file
+type catalogWriter interface {
+	writeRow(cells []any) error
+	close() error
+}
+
+// GetProductExport streams the catalogue while it is read from the database in batches
+func (s *ProductServer) GetProductExport(ctx context.Context, request GetProductExportRequestObject) (GetProductExportResponseObject, error) {
+	format := Csv
+	if request.Params.Format != nil {
+		format = *request.Params.Format
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		// the request context is recycled once the handler returns, which happens before the export ends when
+		// the client goes away. The export then stops at the next write to the closed pipe.
+		err := s.exportCatalog(context.Background(), writer, format)
+		if err != nil && !errors.Is(err, io.ErrClosedPipe) {
+			s.sc.Log.Error("failed to export products", zap.Error(err))
+		}
+		writer.CloseWithError(err)
+	}()
+
+	headers := GetProductExport200ResponseHeaders{
+		ContentDisposition: fmt.Sprintf(`attachment; filename="products-%s.%s"`, time.Now().Format("20060102-150405"), format),
+	}
+	if format == Xlsx {
+		return GetProductExport200ApplicationvndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse{
+			Body:    reader,
+			Headers: headers,
+		}, nil
+	}
+	return GetProductExport200TextcsvResponse{
+		Body:    reader,
+		Headers: headers,
+	}, nil
+}
+
+// exportCatalog writes one row per active option. The products are read in a read only snapshot so a
+// catalogue that changes during the export is written as it was when the export started.
+func (s *ProductServer) exportCatalog(ctx context.Context, w io.Writer, format GetProductExportParamsFormat) error {
+	tx := s.sc.DB.WithContext(ctx).Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+
+	var out catalogWriter
+	if format == Xlsx {
+		x := newXLSXCatalogWriter(w)
+		defer x.file.Close()
+		out = x
+	} else {
+		out = newCSVCatalogWriter(w)
+	}
+
+	header := make([]any, 0, len(catalogColumns))
+	for _, column := range catalogColumns {
+		header = append(header, column)
+	}
+	if err := out.writeRow(header); err != nil {
+		return err
+	}
+
+	sort := "code"
+	preloads := []database.PreloadData{
+		{Field: "Options", Args: []interface{}{exportedOptions}},
+		{Field: "Tags"},
+	}
+	cond := map[string]any{"deleted_at" + database.CONDITION_NULL: nil}
+	for {
+		products, err := s.sc.ProductRepo.WithTx(tx).FindWithJoinAndPreload(ctx, cond, []string{}, exportBatchSize, 0, &sort, []string{}, preloads)
+		if err != nil {
+			return err
+		}
+		for _, p := range products {
+			if err := writeProductRows(out, p); err != nil {
+				return err
+			}
+		}
+		if len(products) < exportBatchSize {
+			break
+		}
+		// codes are unique, the next batch starts after the last one written
+		cond["code"+database.CONDITION_GREATER_THAN] = products[len(products)-1].Code
+	}
+
+	return out.close()
+}
+
+// exportedOptions is the preload scope of the options written to an export
+func exportedOptions(db *gorm.DB) *gorm.DB {
+	return db.Where("archived_at IS NULL").Order("code")
+}
+
+func writeProductRows(out catalogWriter, p models.Product) error {
+	categoryID := ""
+	if p.CategoryID != nil {
+		categoryID = p.CategoryID.String()
+	}
+	tags := make([]string, 0, len(p.Tags))
+	for _, t := range p.Tags {
+		tags = append(tags, t.Name)
+	}
+	slices.Sort(tags)
+	product := []any{p.Code, p.Name, categoryID, strings.Join(tags, ", "), p.Img}
+
+	if len(p.Options) == 0 {
+		return out.writeRow(append(product, "", "", "", ""))
+	}
+	for _, o := range p.Options {
+		row := append(slices.Clone(product), o.Code, o.Name, o.Quantity, o.Price)
+		if err := out.writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type csvCatalogWriter struct {
+	w *csv.Writer
+}
+
+// newCSVCatalogWriter writes UTF-8 CSV with a byte order mark, spreadsheets otherwise guess the encoding
+func newCSVCatalogWriter(w io.Writer) *csvCatalogWriter {
+	w.Write([]byte("\ufeff"))
+	return &csvCatalogWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvCatalogWriter) writeRow(cells []any) error {
+	record := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		switch v := cell.(type) {
+		case string:
+			record = append(record, escapeCell(v))
+		case int:
+			record = append(record, strconv.Itoa(v))
+		case decimal.Decimal:
+			record = append(record, v.String())
+		case uuid.UUID:
+			record = append(record, v.String())
+		default:
+			record = append(record, fmt.Sprint(v))
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvCatalogWriter) close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// xlsxCatalogWriter buffers the sheet in a temporary file, the workbook is written once all rows are in
+type xlsxCatalogWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+	err    error
+}
+
+func newXLSXCatalogWriter(w io.Writer) *xlsxCatalogWriter {
+	file := excelize.NewFile()
+	x := &xlsxCatalogWriter{w: w, file: file}
+	x.err = file.SetSheetName(file.GetSheetName(0), exportSheet)
+	if x.err == nil {
+		x.stream, x.err = file.NewStreamWriter(exportSheet)
+	}
+	return x
+}
+
+func (x *xlsxCatalogWriter) writeRow(cells []any) error {
+	if x.err != nil {
+		return x.err
+	}
+	for i, cell := range cells {
+		// prices are written as numbers so they can be calculated with
+		if v, ok := cell.(decimal.Decimal); ok {
+			cells[i] = v.InexactFloat64()
+		}
+	}
+	x.row++
+	name, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(name, cells)
+}
+
+func (x *xlsxCatalogWriter) close() error {
+	if x.err != nil {
+		return x.err
+	}
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.w)
+}
+
+// escapeCell quotes CSV cells that spreadsheets would read as a formula, the import removes the quote again
+func escapeCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,484 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strconv"
+
+	"github.com/LeHNam/wao-api/helpers/utils"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/imaging"
+	"github.com/LeHNam/wao-api/services/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	errImageMissing  = errors.New("file is required")
+	errImageTooLarge = errors.New("image is too large")
+)
+
+// imageUpload is the parsed multipart body of an image upload
+type imageUpload struct {
+	data    []byte
+	primary bool
+}
+
+func (s *ProductServer) GetProductIdImages(ctx context.Context, request GetProductIdImagesRequestObject) (GetProductIdImagesResponseObject, error) {
+	id, err := uuid.Parse(request.Id)
+	if err != nil {
+		return GetProductIdImages404JSONResponse{
+			Message: "id not valid",
+		}, nil
+	}
+	if _, err := s.sc.ProductRepo.First(ctx, id); err != nil {
+		return GetProductIdImages404JSONResponse{
+			Message: "id not found",
+		}, nil
+	}
+
+	sort := "position"
+	images, err := s.sc.ProductImageRepo.Find(ctx, map[string]any{"product_id": id}, []string{}, 0, 0, &sort)
+	if err != nil {
+		s.sc.Log.Error("failed to get product images", zap.Error(err))
+		return GetProductIdImages404JSONResponse{
+			Message: "failed to get product images",
+		}, nil
+	}
+
+	return GetProductIdImages200JSONResponse{
+		Items: s.toProductImages(images),
+	}, nil
+}
+
+// PostProductIdImages stores the original upload and its thumbnails before the image is recorded, the
+// stored files are removed again when the image can not be saved.
+func (s *ProductServer) PostProductIdImages(ctx context.Context, request PostProductIdImagesRequestObject) (PostProductIdImagesResponseObject, error) {
+	id, err := uuid.Parse(request.Id)
+	if err != nil {
+		return PostProductIdImages404JSONResponse{
+			Message: "id not valid",
+		}, nil
+	}
+	if _, err := s.sc.ProductRepo.First(ctx, id); err != nil {
+		return PostProductIdImages404JSONResponse{
+			Message: "id not found",
+		}, nil
+	}
+
+	upload, err := s.readImageUpload(request.Body)
+	if errors.Is(err, errImageTooLarge) {
+		return PostProductIdImages413JSONResponse{
+			Message: fmt.Sprintf("image must not be larger than %d bytes", s.sc.Config.ProductImage.MaxSize),
+		}, nil
+	}
+	if err != nil {
+		return PostProductIdImages400JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+
+	img, err := imaging.Decode(upload.data)
+	if err != nil {
+		return PostProductIdImages400JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+
+	image := &models.ProductImage{
+		ID:          uuid.New(),
+		ProductID:   id,
+		ContentType: img.ContentType,
+		Size:        int64(len(upload.data)),
+		Width:       img.Width,
+		Height:      img.Height,
+		Thumbnails:  []models.ProductImageThumbnail{},
+	}
+	if userCtx := utils.GetUserFromContext(ctx); userCtx != nil {
+		image.CreatedBy = userCtx.ID
+	}
+	image.Key = fmt.Sprintf("products/%s/%s%s", id, image.ID, img.Ext)
+
+	stored := make([]string, 0, len(s.sc.Config.ProductImage.ThumbnailSizes)+1)
+	saved := false
+	defer func() {
+		if !saved {
+			s.deleteImageFiles(stored)
+		}
+	}()
+
+	if err := s.sc.Storage.Put(ctx, image.Key, bytes.NewReader(upload.data), image.ContentType); err != nil {
+		s.sc.Log.Error("failed to store product image", zap.Error(err))
+		return PostProductIdImages400JSONResponse{
+			Message: "failed to store image",
+		}, nil
+	}
+	stored = append(stored, image.Key)
+
+	for _, size := range s.sc.Config.ProductImage.ThumbnailSizes {
+		thumb, err := img.Thumbnail(size)
+		if err == nil {
+			key := fmt.Sprintf("products/%s/%s_%d%s", id, image.ID, size, thumb.Ext)
+			err = s.sc.Storage.Put(ctx, key, bytes.NewReader(thumb.Data), thumb.ContentType)
+			if err == nil {
+				stored = append(stored, key)
+				image.Thumbnails = append(image.Thumbnails, models.ProductImageThumbnail{
+					Size:   size,
+					Key:    key,
+					Width:  thumb.Width,
+					Height: thumb.Height,
+				})
+			}
+		}
+		if err != nil {
+			s.sc.Log.Error("failed to store product image thumbnail", zap.Int("size", size), zap.Error(err))
+			return PostProductIdImages400JSONResponse{
+				Message: "failed to store image",
+			}, nil
+		}
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PostProductIdImages400JSONResponse{
+			Message: "Create DB transaction failed",
+		}, nil
+	}
+	defer tx.Rollback()
+
+	// the product row serializes changes to the order and the primary flag of its images
+	if _, err := s.sc.ProductRepo.WithTx(tx).FirstForUpdate(ctx, id); err != nil {
+		return PostProductIdImages404JSONResponse{
+			Message: "id not found",
+		}, nil
+	}
+	count, err := s.sc.ProductImageRepo.WithTx(tx).Count(ctx, map[string]any{"product_id": id})
+	if err == nil {
+		image.Position = int(count)
+		image.IsPrimary = count == 0 || upload.primary
+		if image.IsPrimary {
+			err = s.setPrimaryImage(ctx, tx, image)
+		}
+	}
+	if err == nil {
+		err = s.sc.ProductImageRepo.WithTx(tx).Create(ctx, image)
+	}
+	if err == nil {
+		err = tx.Commit().Error
+	}
+	if err != nil {
+		s.sc.Log.Error("failed to save product image", zap.Error(err))
+		return PostProductIdImages400JSONResponse{
+			Message: "failed to save image",
+		}, nil
+	}
+	saved = true
+
+	return PostProductIdImages201JSONResponse(s.toProductImage(image)), nil
+}
+
+// PatchProductIdImagesImageId moves an image to another position or makes it the primary image
+func (s *ProductServer) PatchProductIdImagesImageId(ctx context.Context, request PatchProductIdImagesImageIdRequestObject) (PatchProductIdImagesImageIdResponseObject, error) {
+	image, err := s.findImage(ctx, request.Id, request.ImageId)
+	if err != nil {
+		return PatchProductIdImagesImageId404JSONResponse{
+			Message: "product image not found",
+		}, nil
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PatchProductIdImagesImageId404JSONResponse{
+			Message: "Create DB transaction failed",
+		}, nil
+	}
+	defer tx.Rollback()
+
+	if _, err := s.sc.ProductRepo.WithTx(tx).FirstForUpdate(ctx, image.ProductID); err != nil {
+		return PatchProductIdImagesImageId404JSONResponse{
+			Message: "product image not found",
+		}, nil
+	}
+	sort := "position"
+	images, err := s.sc.ProductImageRepo.WithTx(tx).Find(ctx, map[string]any{"product_id": image.ProductID}, []string{}, 0, 0, &sort)
+	if err != nil {
+		s.sc.Log.Error("failed to load product images", zap.Error(err))
+		return PatchProductIdImagesImageId404JSONResponse{
+			Message: "update failed",
+		}, nil
+	}
+
+	index := -1
+	for i := range images {
+		if images[i].ID == image.ID {
+			index = i
+		}
+	}
+	if index < 0 {
+		return PatchProductIdImagesImageId404JSONResponse{
+			Message: "product image not found",
+		}, nil
+	}
+	image = &images[index]
+
+	if request.Body.Position != nil {
+		target := min(max(*request.Body.Position, 0), len(images)-1)
+		moved := images[index]
+		images = append(images[:index], images[index+1:]...)
+		images = append(images[:target], append([]models.ProductImage{moved}, images[target:]...)...)
+		image = &images[target]
+		if err := s.renumberImages(ctx, tx, images); err != nil {
+			s.sc.Log.Error("failed to reorder product images", zap.Error(err))
+			return PatchProductIdImagesImageId404JSONResponse{
+				Message: "update failed",
+			}, nil
+		}
+	}
+
+	if request.Body.Primary != nil && *request.Body.Primary && !image.IsPrimary {
+		if err := s.setPrimaryImage(ctx, tx, image); err == nil {
+			err = s.sc.ProductImageRepo.WithTx(tx).Update(ctx, image.ID, map[string]any{"is_primary": true})
+		}
+		if err != nil {
+			s.sc.Log.Error("failed to set primary product image", zap.Error(err))
+			return PatchProductIdImagesImageId404JSONResponse{
+				Message: "update failed",
+			}, nil
+		}
+		image.IsPrimary = true
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit product image", zap.Error(err))
+		return PatchProductIdImagesImageId404JSONResponse{
+			Message: "update failed",
+		}, nil
+	}
+
+	return PatchProductIdImagesImageId200JSONResponse(s.toProductImage(image)), nil
+}
+
+// DeleteProductIdImagesImageId removes an image, the next image in order becomes primary when the primary
+// image is deleted. The files are removed after the image is deleted from the database.
+func (s *ProductServer) DeleteProductIdImagesImageId(ctx context.Context, request DeleteProductIdImagesImageIdRequestObject) (DeleteProductIdImagesImageIdResponseObject, error) {
+	image, err := s.findImage(ctx, request.Id, request.ImageId)
+	if err != nil {
+		return DeleteProductIdImagesImageId404JSONResponse{
+			Message: "product image not found",
+		}, nil
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return DeleteProductIdImagesImageId404JSONResponse{
+			Message: "Create DB transaction failed",
+		}, nil
+	}
+	defer tx.Rollback()
+
+	product, err := s.sc.ProductRepo.WithTx(tx).FirstForUpdate(ctx, image.ProductID)
+	if err != nil {
+		return DeleteProductIdImagesImageId404JSONResponse{
+			Message: "product image not found",
+		}, nil
+	}
+	sort := "position"
+	images, err := s.sc.ProductImageRepo.WithTx(tx).Find(ctx, map[string]any{"product_id": image.ProductID}, []string{}, 0, 0, &sort)
+	if err != nil {
+		s.sc.Log.Error("failed to load product images", zap.Error(err))
+		return DeleteProductIdImagesImageId404JSONResponse{
+			Message: "delete failed",
+		}, nil
+	}
+
+	remaining := make([]models.ProductImage, 0, len(images))
+	var deleted *models.ProductImage
+	for i := range images {
+		if images[i].ID == image.ID {
+			deleted = &images[i]
+			continue
+		}
+		remaining = append(remaining, images[i])
+	}
+	if deleted == nil {
+		return DeleteProductIdImagesImageId404JSONResponse{
+			Message: "product image not found",
+		}, nil
+	}
+
+	err = s.sc.ProductImageRepo.WithTx(tx).Delete(ctx, deleted.ID)
+	if err == nil {
+		err = s.renumberImages(ctx, tx, remaining)
+	}
+	if err == nil && deleted.IsPrimary {
+		if len(remaining) > 0 {
+			err = s.setPrimaryImage(ctx, tx, &remaining[0])
+			if err == nil {
+				err = s.sc.ProductImageRepo.WithTx(tx).Update(ctx, remaining[0].ID, map[string]any{"is_primary": true})
+			}
+		} else if product.Img == storage.URL(s.sc.Config, deleted.Key) {
+			err = s.sc.ProductRepo.WithTx(tx).Update(ctx, product.ID, map[string]any{"img": ""})
+		}
+	}
+	if err == nil {
+		err = tx.Commit().Error
+	}
+	if err != nil {
+		s.sc.Log.Error("failed to delete product image", zap.Error(err))
+		return DeleteProductIdImagesImageId404JSONResponse{
+			Message: "delete failed",
+		}, nil
+	}
+
+	keys := []string{deleted.Key}
+	for _, t := range deleted.Thumbnails {
+		keys = append(keys, t.Key)
+	}
+	s.deleteImageFiles(keys)
+
+	return DeleteProductIdImagesImageId204Response{}, nil
+}
+
+// orderImages is the preload scope listing product images in display order
+func orderImages(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+// readImageUpload reads the file and primary fields, the file is read up to the configured size limit
+func (s *ProductServer) readImageUpload(body *multipart.Reader) (*imageUpload, error) {
+	maxSize := s.sc.Config.ProductImage.MaxSize
+	upload := &imageUpload{}
+	for {
+		part, err := body.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("invalid multipart body")
+		}
+
+		switch part.FormName() {
+		case "file":
+			upload.data, err = io.ReadAll(io.LimitReader(part, maxSize+1))
+			if err != nil {
+				return nil, errors.New("failed to read file")
+			}
+			if int64(len(upload.data)) > maxSize {
+				return nil, errImageTooLarge
+			}
+		case "primary":
+			value, err := io.ReadAll(io.LimitReader(part, 16))
+			if err == nil {
+				upload.primary, err = strconv.ParseBool(string(value))
+			}
+			if err != nil {
+				return nil, errors.New("primary must be true or false")
+			}
+		}
+		part.Close()
+	}
+
+	if len(upload.data) == 0 {
+		return nil, errImageMissing
+	}
+	return upload, nil
+}
+
+// setPrimaryImage clears the primary flag of the other images of the product and shows the image as the
+// img of the product. The caller sets the flag of the image itself.
+func (s *ProductServer) setPrimaryImage(ctx context.Context, tx *gorm.DB, image *models.ProductImage) error {
+	err := s.sc.ProductImageRepo.WithTx(tx).UpdateFields(ctx, map[string]any{
+		"product_id": image.ProductID,
+		"is_primary": true,
+	}, map[string]any{"is_primary": false})
+	if err != nil {
+		return err
+	}
+	return s.sc.ProductRepo.WithTx(tx).Update(ctx, image.ProductID, map[string]any{
+		"img": storage.URL(s.sc.Config, image.Key),
+	})
+}
+
+// renumberImages stores the index of every image as its position
+func (s *ProductServer) renumberImages(ctx context.Context, tx *gorm.DB, images []models.ProductImage) error {
+	for i := range images {
+		if images[i].Position == i {
+			continue
+		}
+		if err := s.sc.ProductImageRepo.WithTx(tx).Update(ctx, images[i].ID, map[string]any{"position": i}); err != nil {
+			return err
+		}
+		images[i].Position = i
+	}
+	return nil
+}
+
+// findImage loads a product image and checks that it belongs to the given product
+func (s *ProductServer) findImage(ctx context.Context, productID, imageID string) (*models.ProductImage, error) {
+	pID, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, err
+	}
+	iID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, err
+	}
+	return s.sc.ProductImageRepo.FindOne(ctx, map[string]any{
+		"id":         iID,
+		"product_id": pID,
+	}, []string{})
+}
+
+// deleteImageFiles removes stored files, failures are only logged as the files are no longer referenced
+func (s *ProductServer) deleteImageFiles(keys []string) {
+	for _, key := range keys {
+		if err := s.sc.Storage.Delete(context.Background(), key); err != nil {
+			s.sc.Log.Error("failed to delete stored image", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+func (s *ProductServer) toProductImage(image *models.ProductImage) ProductImage {
+	thumbnails := make([]ProductImageThumbnail, 0, len(image.Thumbnails))
+	for _, t := range image.Thumbnails {
+		thumbnails = append(thumbnails, ProductImageThumbnail{
+			Size:   t.Size,
+			Url:    storage.URL(s.sc.Config, t.Key),
+			Width:  t.Width,
+			Height: t.Height,
+		})
+	}
+	return ProductImage{
+		Id:          image.ID.String(),
+		Url:         storage.URL(s.sc.Config, image.Key),
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		Width:       image.Width,
+		Height:      image.Height,
+		Position:    image.Position,
+		Primary:     image.IsPrimary,
+		Thumbnails:  thumbnails,
+		CreatedAt:   image.CreatedAt,
+	}
+}
+
+func (s *ProductServer) toProductImages(images []models.ProductImage) []ProductImage {
+	items := make([]ProductImage, 0, len(images))
+	for i := range images {
+		items = append(items, s.toProductImage(&images[i]))
+	}
+	return items
+}
+
+func (s *ProductServer) productImages(images []models.ProductImage) *[]ProductImage {
+	items := s.toProductImages(images)
+	return &items
+}
@@ -2,8 +2,11 @@ package product
 
 import (
 	"context"
+	"errors"
+	"github.com/LeHNam/wao-api/constant"
 	"github.com/LeHNam/wao-api/helpers/utils"
 	"github.com/LeHNam/wao-api/services/database"
+	"github.com/LeHNam/wao-api/services/outbox"
 	"github.com/LeHNam/wao-api/services/websocket"
 	"time"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/LeHNam/wao-api/models"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type ProductServer struct {
@@ -29,11 +33,31 @@ func (s *ProductServer) GetProduct(ctx context.Context, request GetProductReques
 	cond := map[string]any{}
 
 	userCtx := utils.GetUserFromContext(ctx)
+	var search *productSearch
 	if request.Params.Search != nil {
-		cond["OR"] = []map[string]any{
-			{"products.name LIKE": "%" + *request.Params.Search + "%"},
-			{"products.code LIKE": "%" + *request.Params.Search + "%"},
+		search = newProductSearch(*request.Params.Search)
+	}
+	if search != nil {
+		// products.id IN is taken by the tag filter
+		cond["id"+database.CONDITION_IN] = search.matching(s.sc.ProductRepo.GetDB())
+	}
+	if request.Params.CategoryId != nil {
+		id, err := uuid.Parse(*request.Params.CategoryId)
+		if err != nil {
+			return GetProduct400JSONResponse{
+				Message: "category_id not valid",
+			}, nil
+		}
+		categoryIDs, err := s.categoryTree(ctx, id)
+		if err != nil {
+			return GetProduct400JSONResponse{
+				Message: "category not found",
+			}, nil
 		}
+		cond["products.category_id"+database.CONDITION_IN] = categoryIDs
+	}
+	if request.Params.Tag != nil {
+		cond["products.id"+database.CONDITION_IN] = s.taggedProducts(*request.Params.Tag)
 	}
 
 	sort := request.Params.Sort
@@ -42,19 +66,37 @@ func (s *ProductServer) GetProduct(ctx context.Context, request GetProductReques
 	offset := (page - 1) * limit
 
 	preloads := []database.PreloadData{
-		{Field: "Options"},
+		{Field: "Options", Args: []interface{}{"archived_at IS NULL"}},
+		{Field: "Tags"},
+		{Field: "Images", Args: []interface{}{orderImages}},
 	}
 
 	// Initialize empty joins array for SQL JOIN clauses
 	joins := []string{}
-	if userCtx != nil && userCtx.Role != "BUYER" {
+	if userCtx != nil && userCtx.Role != constant.RoleBuyer {
 		preloads = []database.PreloadData{
-			{Field: "Options", Args: []interface{}{"quantity > 0 AND PRICE > 0"}},
+			{Field: "Options", Args: []interface{}{"archived_at IS NULL AND quantity > 0 AND PRICE > 0"}},
+			{Field: "Tags"},
+			{Field: "Images", Args: []interface{}{orderImages}},
+		}
+	}
+	var products []models.Product
+	var err error
+	if search != nil && sort != nil && *sort == sortRelevance {
+		var ids []uuid.UUID
+		ids, err = s.rankedProductIDs(ctx, search, cond, limit, offset)
+		if err == nil {
+			products, err = s.sc.ProductRepo.FindWithJoinAndPreload(ctx, map[string]any{
+				"products.id" + database.CONDITION_IN: ids,
+			}, []string{}, 0, 0, nil, joins, preloads)
+			sortProducts(products, ids)
 		}
+	} else {
+		products, err = s.sc.ProductRepo.FindWithJoinAndPreload(ctx, cond, []string{}, limit, offset, sort, joins, preloads)
 	}
-	products, err := s.sc.ProductRepo.FindWithJoinAndPreload(ctx, cond, []string{}, limit, offset, sort, joins, preloads)
 	total, _ := s.sc.ProductRepo.CountWithJoin(ctx, cond, joins)
 	if err != nil {
+		s.sc.Log.Error("failed to get list of products", zap.Error(err))
 		return GetProduct400JSONResponse{
 			Message: "failed to get list of products",
 		}, nil
@@ -69,15 +111,18 @@ func (s *ProductServer) GetProduct(ctx context.Context, request GetProductReques
 				Name:     po.Name,
 				Code:     po.Code,
 				Quantity: po.Quantity,
-				Price:    float32(po.Price),
+				Price:    po.Price,
 			})
 		}
 		items = append(items, Product{
-			Id:      p.ID.String(),
-			Name:    p.Name,
-			Code:    p.Code,
-			Img:     p.Img,
-			Options: options,
+			Id:         p.ID.String(),
+			Name:       p.Name,
+			Code:       p.Code,
+			Img:        p.Img,
+			CategoryId: categoryID(p.CategoryID),
+			Tags:       tagNames(p.Tags),
+			Images:     s.productImages(p.Images),
+			Options:    options,
 		})
 
 	}
@@ -102,31 +147,81 @@ func (s *ProductServer) PostProduct(ctx context.Context, request PostProductRequ
 			Name:     o.Name,
 			Code:     o.Code,
 			Quantity: o.Quantity,
-			Price:    float64(o.Price),
+			Price:    models.RoundMoney(o.Price, s.sc.Config.Currency.Base),
 		})
 	}
 
 	productModel := &models.Product{
-		ID:        uuid.New(),
-		Name:      request.Body.Name,
-		Code:      request.Body.Code,
-		Img:       request.Body.Img,
-		Options:   options,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:         uuid.New(),
+		Name:       request.Body.Name,
+		Code:       request.Body.Code,
+		Img:        request.Body.Img,
+		CategoryID: request.Body.CategoryId,
+		Options:    options,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if productModel.CategoryID != nil {
+		if _, err := s.sc.CategoryRepo.First(ctx, *productModel.CategoryID); err != nil {
+			return PostProduct400JSONResponse{
+				Message: "category not found",
+			}, nil
+		}
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PostProduct400JSONResponse{
+			Message: "Create DB transaction failed",
+		}, nil
+	}
+	defer tx.Rollback()
+
+	if request.Body.Tags != nil {
+		tags, err := s.resolveTags(ctx, tx, *request.Body.Tags)
+		if err != nil {
+			s.sc.Log.Error("failed to save product tags", zap.Error(err))
+			return PostProduct400JSONResponse{
+				Message: "failed to create product",
+			}, nil
+		}
+		productModel.Tags = tags
 	}
-	err := s.sc.ProductRepo.Create(ctx, productModel)
 
+	err := s.sc.ProductRepo.WithTx(tx).Create(ctx, productModel)
 	if err != nil {
 		return PostProduct400JSONResponse{
 			Message: "failed to create product",
 		}, nil
 	}
-	message := map[string]any{
-		"event": "product_created",
-		"data":  productModel,
+
+	err = s.recordManualAdjustments(ctx, tx, productModel.ID, nil, options)
+	if err != nil {
+		s.sc.Log.Error("failed to record stock movements", zap.Error(err))
+		return PostProduct400JSONResponse{
+			Message: "failed to create product",
+		}, nil
 	}
-	_ = s.wsService.Broadcast(message)
+
+	event, err := outbox.NewEvent(string(websocket.EventProductCreated), productModel, uuid.Nil, websocket.ProductTopic(productModel.ID))
+	if err == nil {
+		err = s.sc.OutboxEventRepo.WithTx(tx).Create(ctx, event)
+	}
+	if err != nil {
+		s.sc.Log.Error("failed to write product event", zap.Error(err))
+		return PostProduct400JSONResponse{
+			Message: "failed to create product",
+		}, nil
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit product", zap.Error(err))
+		return PostProduct400JSONResponse{
+			Message: "failed to create product",
+		}, nil
+	}
+	s.sc.Outbox.Notify()
 
 	return PostProduct201JSONResponse{
 		Message: nil,
@@ -144,13 +239,44 @@ func (s *ProductServer) DeleteProductId(ctx context.Context, request DeleteProdu
 			Message: "id not valid",
 		}, nil
 	}
-	err = s.sc.ProductRepo.Delete(ctx, id)
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return DeleteProductId404JSONResponse{
+			Message: "Create DB transaction failed",
+		}, nil
+	}
+	defer tx.Rollback()
+
+	// the image rows cascade with the product, they are loaded first only to collect the stored file keys
+	images, err := s.sc.ProductImageRepo.WithTx(tx).Find(ctx, map[string]any{"product_id": id}, []string{}, 0, 0, nil)
+	if err != nil {
+		s.sc.Log.Error("failed to load product images", zap.Error(err))
+		return DeleteProductId404JSONResponse{
+			Message: "delete product failed",
+		}, nil
+	}
+	err = s.sc.ProductRepo.WithTx(tx).Delete(ctx, id)
+	if err == nil {
+		err = tx.Commit().Error
+	}
 	if err != nil {
 		s.sc.Log.Error("failed to delete product", zap.Error(err))
 		return DeleteProductId404JSONResponse{
 			Message: "delete product failed",
 		}, nil
 	}
+
+	keys := make([]string, 0, len(images))
+	for _, image := range images {
+		keys = append(keys, image.Key)
+		for _, t := range image.Thumbnails {
+			keys = append(keys, t.Key)
+		}
+	}
+	s.deleteImageFiles(keys)
+
 	return DeleteProductId204Response{}, nil
 }
 
@@ -162,14 +288,20 @@ func (s *ProductServer) GetProductId(ctx context.Context, request GetProductIdRe
 		}, nil
 	}
 
-	product, err := s.sc.ProductRepo.First(ctx, id)
-	if err != nil {
+	products, err := s.sc.ProductRepo.FindWithJoinAndPreload(ctx, map[string]any{"id": id}, []string{}, 1, 0, nil, []string{}, []database.PreloadData{
+		{Field: "Tags"},
+		{Field: "Images", Args: []interface{}{orderImages}},
+	})
+	if err != nil || len(products) == 0 {
 		return GetProductId404JSONResponse{
 			Message: "id not found",
 		}, nil
 	}
 
-	productOptions, err := s.sc.ProductOptionRepo.Find(ctx, map[string]any{"product_id": id}, []string{}, 0, 0, nil)
+	productOptions, err := s.sc.ProductOptionRepo.Find(ctx, map[string]any{
+		"product_id":                            id,
+		"archived_at" + database.CONDITION_NULL: nil,
+	}, []string{}, 0, 0, nil)
 	options := make([]ProductOption, len(productOptions))
 	for i, o := range productOptions {
 		options[i] = ProductOption{
@@ -177,18 +309,21 @@ func (s *ProductServer) GetProductId(ctx context.Context, request GetProductIdRe
 			Name:     o.Name,
 			Code:     o.Code,
 			Quantity: o.Quantity,
-			Price:    float32(o.Price),
+			Price:    o.Price,
 		}
 	}
 
 	return GetProductId200JSONResponse{
 		Message: nil,
 		Data: Product{
-			Id:      product.ID.String(),
-			Name:    product.Name,
-			Code:    product.Code,
-			Img:     product.Img,
-			Options: options,
+			Id:         products[0].ID.String(),
+			Name:       products[0].Name,
+			Code:       products[0].Code,
+			Img:        products[0].Img,
+			CategoryId: categoryID(products[0].CategoryID),
+			Tags:       tagNames(products[0].Tags),
+			Images:     s.productImages(products[0].Images),
+			Options:    options,
 		},
 	}, nil
 }
@@ -200,6 +335,14 @@ func (s *ProductServer) PutProductId(ctx context.Context, request PutProductIdRe
 			Message: "id not valid",
 		}, nil
 	}
+	if request.Body.CategoryId != nil {
+		if _, err := s.sc.CategoryRepo.First(ctx, *request.Body.CategoryId); err != nil {
+			return PutProductId400JSONResponse{
+				Message: "category not found",
+			}, nil
+		}
+	}
+
 	tx := s.sc.DB.Begin().WithContext(ctx)
 	if tx.Error != nil {
 		s.sc.Log.Error(tx.Error.Error())
@@ -209,38 +352,21 @@ func (s *ProductServer) PutProductId(ctx context.Context, request PutProductIdRe
 	}
 	defer tx.Rollback()
 
+	changes := &optionChanges{}
 	if request.Body.Options != nil {
-		// Delete existing options for the product
-		err = s.sc.ProductOptionRepo.WithTx(tx).DeleteWhere(ctx, map[string]any{"product_id": id})
+		changes, err = s.syncOptions(ctx, tx, id, *request.Body.Options)
 		if err != nil {
-			s.sc.Log.Error("failed to delete existing product options", zap.Error(err))
+			var optionErr *OptionUpdateError
+			if errors.As(err, &optionErr) {
+				return PutProductId400JSONResponse{
+					Message: optionErr.Message,
+				}, nil
+			}
+			s.sc.Log.Error("failed to update product options", zap.Error(err))
 			return PutProductId404JSONResponse{
 				Message: "update failed",
 			}, nil
 		}
-
-		// Add new options
-		options := make([]models.ProductOption, 0)
-		for _, o := range *request.Body.Options {
-			options = append(options, models.ProductOption{
-				ID:        uuid.New(),
-				ProductID: id,
-				Name:      o.Name,
-				Code:      o.Code,
-				Quantity:  o.Quantity,
-				Price:     float64(o.Price),
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			})
-		}
-		err = s.sc.ProductOptionRepo.WithTx(tx).CreateMany(ctx, options)
-		if err != nil {
-			s.sc.Log.Error("failed to create product options", zap.Error(err))
-			return PutProductId404JSONResponse{
-				Message: "update failed",
-			}, nil
-		}
-
 	}
 
 	updateData := map[string]any{
@@ -249,6 +375,10 @@ func (s *ProductServer) PutProductId(ctx context.Context, request PutProductIdRe
 		"img":        request.Body.Img,
 		"updated_at": time.Now(),
 	}
+	// like tags, the category is only changed when the request carries one
+	if request.Body.CategoryId != nil {
+		updateData["category_id"] = request.Body.CategoryId
+	}
 	err = s.sc.ProductRepo.WithTx(tx).Update(ctx, id, updateData)
 	if err != nil {
 		s.sc.Log.Error("failed to update product", zap.Error(err))
@@ -257,10 +387,256 @@ func (s *ProductServer) PutProductId(ctx context.Context, request PutProductIdRe
 		}, nil
 	}
 
-	tx.Commit()
+	product, err := s.sc.ProductRepo.WithTx(tx).First(ctx, id)
+	if err != nil {
+		return PutProductId404JSONResponse{
+			Message: "id not found",
+		}, nil
+	}
+	if request.Body.Tags != nil {
+		product.Tags, err = s.resolveTags(ctx, tx, *request.Body.Tags)
+		if err == nil {
+			err = s.sc.ProductRepo.WithTx(tx).GetDB().WithContext(ctx).Model(product).Association("Tags").Replace(product.Tags)
+		}
+	} else {
+		err = s.sc.ProductRepo.WithTx(tx).GetDB().WithContext(ctx).Model(product).Association("Tags").Find(&product.Tags)
+	}
+	if err != nil {
+		s.sc.Log.Error("failed to update product tags", zap.Error(err))
+		return PutProductId404JSONResponse{
+			Message: "update failed",
+		}, nil
+	}
+	if request.Body.Options == nil {
+		changes.active, err = s.sc.ProductOptionRepo.WithTx(tx).Find(ctx, map[string]any{
+			"product_id":                            id,
+			"archived_at" + database.CONDITION_NULL: nil,
+		}, []string{}, 0, 0, nil)
+		if err != nil {
+			s.sc.Log.Error("failed to load product options", zap.Error(err))
+			return PutProductId404JSONResponse{
+				Message: "update failed",
+			}, nil
+		}
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit product", zap.Error(err))
+		return PutProductId404JSONResponse{
+			Message: "update failed",
+		}, nil
+	}
 
 	mess := "update success"
 	return PutProductId200JSONResponse{
 		Message: &mess,
+		Data: Product{
+			Id:         product.ID.String(),
+			Name:       product.Name,
+			Code:       product.Code,
+			Img:        product.Img,
+			CategoryId: categoryID(product.CategoryID),
+			Tags:       tagNames(product.Tags),
+			Options:    toProductOptions(changes.active),
+		},
+		Options: ProductOptionChanges{
+			Created: toProductOptions(changes.created),
+			Updated: toProductOptions(changes.updated),
+			Removed: append([]RemovedProductOption{}, changes.removed...),
+		},
+	}, nil
+}
+
+func (s *ProductServer) GetProductIdOptionsOptionIdStockMovements(ctx context.Context, request GetProductIdOptionsOptionIdStockMovementsRequestObject) (GetProductIdOptionsOptionIdStockMovementsResponseObject, error) {
+	option, err := s.findOption(ctx, request.Id, request.OptionId)
+	if err != nil {
+		return GetProductIdOptionsOptionIdStockMovements404JSONResponse{
+			Message: "product option not found",
+		}, nil
+	}
+
+	page := request.Params.Page
+	limit := request.Params.Limit
+	if page < 1 || limit < 1 {
+		return GetProductIdOptionsOptionIdStockMovements400JSONResponse{
+			Message: "page and limit must be greater than zero",
+		}, nil
+	}
+	offset := (page - 1) * limit
+	sort := "-created_at"
+
+	cond := map[string]any{"product_option_id": option.ID}
+	movements, err := s.sc.StockMovementRepo.Find(ctx, cond, []string{}, limit, offset, &sort)
+	if err != nil {
+		s.sc.Log.Error("failed to get stock movements", zap.Error(err))
+		return GetProductIdOptionsOptionIdStockMovements404JSONResponse{
+			Message: "failed to get stock movements",
+		}, nil
+	}
+	total, _ := s.sc.StockMovementRepo.Count(ctx, cond)
+
+	items := make([]StockMovement, 0, len(movements))
+	for _, m := range movements {
+		item := StockMovement{
+			Id:              m.ID.String(),
+			ProductOptionId: m.ProductOptionID.String(),
+			Delta:           m.Delta,
+			ReservedDelta:   m.ReservedDelta,
+			Reason:          StockMovementReason(m.Reason),
+			CreatedAt:       m.CreatedAt,
+			CreatedBy:       utils.Stp(m.CreatedBy.String()),
+		}
+		if m.SourceID != nil {
+			item.SourceId = utils.Stp(m.SourceID.String())
+		}
+		items = append(items, item)
+	}
+
+	return GetProductIdOptionsOptionIdStockMovements200JSONResponse{
+		Items: items,
+		Limit: limit,
+		Page:  page,
+		Pages: (int(total) + limit - 1) / limit,
+		Total: int(total),
+	}, nil
+}
+
+func (s *ProductServer) PostProductIdOptionsOptionIdStockRebuild(ctx context.Context, request PostProductIdOptionsOptionIdStockRebuildRequestObject) (PostProductIdOptionsOptionIdStockRebuildResponseObject, error) {
+	option, err := s.findOption(ctx, request.Id, request.OptionId)
+	if err != nil {
+		return PostProductIdOptionsOptionIdStockRebuild404JSONResponse{
+			Message: "product option not found",
+		}, nil
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PostProductIdOptionsOptionIdStockRebuild404JSONResponse{
+			Message: "Create DB transaction failed",
+		}, nil
+	}
+	defer tx.Rollback()
+
+	locked, err := s.sc.ProductOptionRepo.WithTx(tx).FirstForUpdate(ctx, option.ID)
+	if err != nil {
+		return PostProductIdOptionsOptionIdStockRebuild404JSONResponse{
+			Message: "product option not found",
+		}, nil
+	}
+
+	var totals struct {
+		Openings int64
+		Quantity int
+		Reserved int
+	}
+	err = s.sc.StockMovementRepo.WithTx(tx).GetDB().WithContext(ctx).
+		Model(&models.StockMovement{}).
+		Select("COUNT(*) FILTER (WHERE reason = ?) AS openings, COALESCE(SUM(delta), 0) AS quantity, COALESCE(SUM(reserved_delta), 0) AS reserved", models.StockReasonOpeningBalance).
+		Where("product_option_id = ?", option.ID).
+		Scan(&totals).Error
+	if err != nil {
+		s.sc.Log.Error("failed to sum stock movements", zap.Error(err))
+		return PostProductIdOptionsOptionIdStockRebuild500JSONResponse{
+			Message: "rebuild failed",
+		}, nil
+	}
+	// without an opening balance the ledger does not hold the stock the option started with
+	if totals.Openings == 0 {
+		return PostProductIdOptionsOptionIdStockRebuild409JSONResponse{
+			Message: "no opening balance recorded for this option",
+		}, nil
+	}
+
+	err = s.sc.ProductOptionRepo.WithTx(tx).Update(ctx, option.ID, map[string]any{
+		"quantity": totals.Quantity,
+		"reserved": totals.Reserved,
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to update product option stock", zap.Error(err))
+		return PostProductIdOptionsOptionIdStockRebuild500JSONResponse{
+			Message: "rebuild failed",
+		}, nil
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit stock rebuild", zap.Error(err))
+		return PostProductIdOptionsOptionIdStockRebuild500JSONResponse{
+			Message: "rebuild failed",
+		}, nil
+	}
+
+	return PostProductIdOptionsOptionIdStockRebuild200JSONResponse{
+		Quantity:         totals.Quantity,
+		Reserved:         totals.Reserved,
+		PreviousQuantity: locked.Quantity,
+		PreviousReserved: locked.Reserved,
 	}, nil
 }
+
+// findOption loads a product option and checks that it belongs to the given product
+func (s *ProductServer) findOption(ctx context.Context, productID, optionID string) (*models.ProductOption, error) {
+	pID, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, err
+	}
+	oID, err := uuid.Parse(optionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.sc.ProductOptionRepo.FindOne(ctx, map[string]any{
+		"id":         oID,
+		"product_id": pID,
+	}, []string{})
+}
+
+// recordManualAdjustments writes ledger entries that take options from their previous quantities to the new ones.
+// Options in both lists get a single entry with the difference, removed options are closed out and new options
+// get an opening balance with their full quantity.
+func (s *ProductServer) recordManualAdjustments(ctx context.Context, tx *gorm.DB, productID uuid.UUID, previous, current []models.ProductOption) error {
+	actor := uuid.Nil
+	if userCtx := utils.GetUserFromContext(ctx); userCtx != nil {
+		actor = userCtx.ID
+	}
+
+	order := make([]uuid.UUID, 0, len(previous)+len(current))
+	deltas := make(map[uuid.UUID]int, len(previous)+len(current))
+	existing := make(map[uuid.UUID]bool, len(previous))
+	addDelta := func(optionID uuid.UUID, delta int) {
+		if _, ok := deltas[optionID]; !ok {
+			order = append(order, optionID)
+		}
+		deltas[optionID] += delta
+	}
+	for _, o := range previous {
+		existing[o.ID] = true
+		addDelta(o.ID, -o.Quantity)
+	}
+	for _, o := range current {
+		addDelta(o.ID, o.Quantity)
+	}
+
+	movements := make([]models.StockMovement, 0, len(order))
+	for _, optionID := range order {
+		reason := models.StockReasonManualAdjustment
+		if !existing[optionID] {
+			// new options always get one, even when they start empty
+			reason = models.StockReasonOpeningBalance
+		} else if deltas[optionID] == 0 {
+			continue
+		}
+		movements = append(movements, models.StockMovement{
+			ID:              uuid.New(),
+			ProductOptionID: optionID,
+			Delta:           deltas[optionID],
+			Reason:          reason,
+			SourceID:        &productID,
+			CreatedBy:       actor,
+		})
+	}
+
+	if len(movements) == 0 {
+		return nil
+	}
+	return s.sc.StockMovementRepo.WithTx(tx).CreateMany(ctx, movements)
+}
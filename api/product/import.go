@@ -0,0 +1,809 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LeHNam/wao-api/helpers/utils"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/LeHNam/wao-api/services/outbox"
+	"github.com/LeHNam/wao-api/services/websocket"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Columns of the import and export files
+const (
+	columnProductCode = "product_code"
+	columnProductName = "product_name"
+	columnCategoryID  = "category_id"
+	columnTags        = "tags"
+	columnImg         = "img"
+	columnOptionCode  = "option_code"
+	columnOptionName  = "option_name"
+	columnQuantity    = "quantity"
+	columnPrice       = "price"
+)
+
+// catalogColumns lists the columns in the order they are exported
+var catalogColumns = []string{
+	columnProductCode,
+	columnProductName,
+	columnCategoryID,
+	columnTags,
+	columnImg,
+	columnOptionCode,
+	columnOptionName,
+	columnQuantity,
+	columnPrice,
+}
+
+var requiredColumns = []string{
+	columnProductCode,
+	columnProductName,
+	columnOptionCode,
+	columnOptionName,
+	columnQuantity,
+	columnPrice,
+}
+
+// xlsxUnzipRatio bounds the unpacked size of an XLSX file as a multiple of the upload limit
+const xlsxUnzipRatio = 16
+
+var (
+	errImportMissing  = errors.New("file is required")
+	errImportTooLarge = errors.New("file is too large")
+	errImportRows     = errors.New("file has too many rows")
+)
+
+// importUpload is the parsed multipart body of an import
+type importUpload struct {
+	data   []byte
+	dryRun bool
+}
+
+// importRecord is a row of an import file with its row number
+type importRecord struct {
+	line  int
+	cells []string
+}
+
+// importOption is an option row of an import, current is the option it updates
+type importOption struct {
+	line     int
+	code     string
+	name     string
+	quantity int
+	price    decimal.Decimal
+	current  *models.ProductOption
+}
+
+// importProduct gathers the rows of a product, current is the product it updates
+type importProduct struct {
+	line       int
+	code       string
+	name       string
+	categoryID *uuid.UUID
+	tags       []string
+	img        string
+	options    []*importOption
+	current    *models.Product
+	// currentTags are the sorted tag names of the current product
+	currentTags []string
+}
+
+// catalogImport is a parsed import file, the optional columns are only applied when they are in the file
+type catalogImport struct {
+	hasCategory bool
+	hasTags     bool
+	hasImg      bool
+	rows        int
+	products    []*importProduct
+	errors      []ProductImportError
+}
+
+func (c *catalogImport) fail(line int, column, message string) {
+	e := ProductImportError{Row: line, Message: message}
+	if column != "" {
+		e.Column = utils.Stp(column)
+	}
+	c.errors = append(c.errors, e)
+}
+
+// PostProductImport validates every row of the file before anything is saved, the rows are then applied
+// in a single transaction
+func (s *ProductServer) PostProductImport(ctx context.Context, request PostProductImportRequestObject) (PostProductImportResponseObject, error) {
+	upload, err := s.readImportUpload(request.Body)
+	if errors.Is(err, errImportTooLarge) {
+		return PostProductImport413JSONResponse{
+			Message: fmt.Sprintf("file must not be larger than %d bytes", s.sc.Config.ProductImport.MaxSize),
+		}, nil
+	}
+	if err != nil {
+		return PostProductImport400JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+
+	records, err := s.readImportRecords(upload.data)
+	if errors.Is(err, errImportRows) {
+		return PostProductImport413JSONResponse{
+			Message: fmt.Sprintf("file must not have more than %d rows", s.sc.Config.ProductImport.MaxRows),
+		}, nil
+	}
+	if err != nil {
+		return PostProductImport400JSONResponse{
+			Message: err.Error(),
+		}, nil
+	}
+
+	file := s.parseImport(records)
+	result := ProductImportResult{
+		DryRun: upload.dryRun,
+		Rows:   file.rows,
+		Errors: []ProductImportError{},
+	}
+	if len(file.errors) > 0 {
+		result.Errors = file.errors
+		return PostProductImport422JSONResponse(result), nil
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PostProductImport400JSONResponse{
+			Message: "Create DB transaction failed",
+		}, nil
+	}
+	defer tx.Rollback()
+
+	if err = s.planImport(ctx, tx, file); err != nil {
+		s.sc.Log.Error("failed to load products for import", zap.Error(err))
+		return PostProductImport400JSONResponse{
+			Message: "import failed",
+		}, nil
+	}
+	result.Products, result.Options = file.counts()
+	if len(file.errors) > 0 {
+		result.Errors = file.errors
+		return PostProductImport422JSONResponse(result), nil
+	}
+	if upload.dryRun {
+		return PostProductImport200JSONResponse(result), nil
+	}
+
+	if err = s.applyImport(ctx, tx, file); err != nil {
+		s.sc.Log.Error("failed to import products", zap.Error(err))
+		return PostProductImport400JSONResponse{
+			Message: "import failed",
+		}, nil
+	}
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit product import", zap.Error(err))
+		return PostProductImport400JSONResponse{
+			Message: "import failed",
+		}, nil
+	}
+	s.sc.Outbox.Notify()
+
+	return PostProductImport200JSONResponse(result), nil
+}
+
+// readImportUpload reads the file and dry_run fields, the file is read up to the configured size limit
+func (s *ProductServer) readImportUpload(body *multipart.Reader) (*importUpload, error) {
+	maxSize := s.sc.Config.ProductImport.MaxSize
+	upload := &importUpload{}
+	for {
+		part, err := body.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("invalid multipart body")
+		}
+
+		switch part.FormName() {
+		case "file":
+			upload.data, err = io.ReadAll(io.LimitReader(part, maxSize+1))
+			if err != nil {
+				return nil, errors.New("failed to read file")
+			}
+			if int64(len(upload.data)) > maxSize {
+				return nil, errImportTooLarge
+			}
+		case "dry_run":
+			value, err := io.ReadAll(io.LimitReader(part, 16))
+			if err == nil {
+				upload.dryRun, err = strconv.ParseBool(string(value))
+			}
+			if err != nil {
+				return nil, errors.New("dry_run must be true or false")
+			}
+		}
+		part.Close()
+	}
+
+	if len(upload.data) == 0 {
+		return nil, errImportMissing
+	}
+	return upload, nil
+}
+
+// readImportRecords reads the rows of an XLSX or CSV file, XLSX files are recognized by their zip header
+func (s *ProductServer) readImportRecords(data []byte) ([]importRecord, error) {
+	maxRows := s.sc.Config.ProductImport.MaxRows
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return readXLSXRecords(data, xlsxUnzipRatio*s.sc.Config.ProductImport.MaxSize, maxRows)
+	}
+	return readCSVRecords(data, maxRows)
+}
+
+// readCSVRecords reads a UTF-8 CSV file separated by commas or, as written by spreadsheets in some locales,
+// by semicolons
+func readCSVRecords(data []byte, maxRows int) ([]importRecord, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if !utf8.Valid(data) {
+		return nil, errors.New("CSV file must be UTF-8 encoded")
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	var records []importRecord
+	// spreadsheets show a quoted cell with line breaks on a single row
+	multiline := 0
+	for {
+		cells, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV file: %w", err)
+		}
+		if len(records) > maxRows {
+			return nil, errImportRows
+		}
+		line, _ := reader.FieldPos(0)
+		last, _ := reader.FieldPos(len(cells) - 1)
+		records = append(records, importRecord{line: line - multiline, cells: cells})
+		multiline += last + strings.Count(cells[len(cells)-1], "\n") - line
+
+		for i := range cells {
+			cells[i] = unescapeCell(cells[i])
+		}
+	}
+	return records, nil
+}
+
+// readXLSXRecords reads the first sheet of an XLSX file
+func readXLSXRecords(data []byte, unzipLimit int64, maxRows int) ([]importRecord, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data), excelize.Options{UnzipSizeLimit: unzipLimit})
+	if err != nil {
+		return nil, errors.New("invalid XLSX file")
+	}
+	defer f.Close()
+
+	rows, err := f.Rows(f.GetSheetName(0))
+	if err != nil {
+		return nil, errors.New("invalid XLSX file")
+	}
+	defer rows.Close()
+
+	var records []importRecord
+	for line := 1; rows.Next(); line++ {
+		// raw values keep numbers from being formatted with the number format of the cell
+		cells, err := rows.Columns(excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, errors.New("invalid XLSX file")
+		}
+		if len(cells) == 0 {
+			continue
+		}
+		if len(records) > maxRows {
+			return nil, errImportRows
+		}
+		records = append(records, importRecord{line: line, cells: cells})
+	}
+	return records, rows.Error()
+}
+
+// parseImport validates the header and the rows of an import file and groups the rows by product
+func (s *ProductServer) parseImport(records []importRecord) *catalogImport {
+	file := &catalogImport{}
+	records = slices.DeleteFunc(records, func(r importRecord) bool {
+		return !slices.ContainsFunc(r.cells, func(cell string) bool { return strings.TrimSpace(cell) != "" })
+	})
+	if len(records) == 0 {
+		file.fail(1, "", "the file is empty")
+		return file
+	}
+
+	header := records[0]
+	columns := make(map[string]int, len(header.cells))
+	for i, name := range header.cells {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		switch {
+		case name == "":
+			continue
+		case !slices.Contains(catalogColumns, name):
+			file.fail(header.line, name, "unknown column")
+		case columns[name] > 0:
+			file.fail(header.line, name, "the column is in the file more than once")
+		default:
+			columns[name] = i + 1
+		}
+	}
+	for _, name := range requiredColumns {
+		if columns[name] == 0 {
+			file.fail(header.line, name, "the column is missing")
+		}
+	}
+	if len(file.errors) > 0 {
+		return file
+	}
+	file.hasCategory = columns[columnCategoryID] > 0
+	file.hasTags = columns[columnTags] > 0
+	file.hasImg = columns[columnImg] > 0
+
+	byCode := make(map[string]*importProduct)
+	for _, record := range records[1:] {
+		file.rows++
+		cell := func(name string) string {
+			i := columns[name] - 1
+			if i < 0 || i >= len(record.cells) {
+				return ""
+			}
+			return strings.TrimSpace(record.cells[i])
+		}
+
+		row := file.parseProduct(record.line, cell)
+		option := file.parseOption(record.line, cell)
+		if row == nil {
+			continue
+		}
+
+		product := byCode[row.code]
+		if product == nil {
+			byCode[row.code] = row
+			file.products = append(file.products, row)
+			product = row
+		} else {
+			file.compareProduct(product, row)
+		}
+		if option == nil {
+			continue
+		}
+		if i := slices.IndexFunc(product.options, func(o *importOption) bool { return o.code == option.code }); i >= 0 {
+			file.fail(option.line, columnOptionCode, fmt.Sprintf("option %s of product %s is already on row %d", option.code, product.code, product.options[i].line))
+			continue
+		}
+		product.options = append(product.options, option)
+	}
+	return file
+}
+
+// parseProduct reads the product columns of a row, nil is returned when they are invalid
+func (c *catalogImport) parseProduct(line int, cell func(string) string) *importProduct {
+	failed := len(c.errors)
+	product := &importProduct{
+		line: line,
+		code: cell(columnProductCode),
+		name: cell(columnProductName),
+		img:  cell(columnImg),
+	}
+	c.checkText(line, columnProductCode, product.code, 100)
+	c.checkText(line, columnProductName, product.name, 255)
+
+	if value := cell(columnCategoryID); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			c.fail(line, columnCategoryID, "not a valid id")
+		}
+		product.categoryID = &id
+	}
+
+	tags := make(map[string]bool)
+	for _, name := range strings.Split(cell(columnTags), ",") {
+		name = models.NormalizeTag(name)
+		if name == "" || tags[name] {
+			continue
+		}
+		if utf8.RuneCountInString(name) > 100 {
+			c.fail(line, columnTags, fmt.Sprintf("tag %s is longer than 100 characters", name))
+		}
+		tags[name] = true
+		product.tags = append(product.tags, name)
+	}
+	slices.Sort(product.tags)
+
+	if len(c.errors) > failed {
+		return nil
+	}
+	return product
+}
+
+// parseOption reads the option columns of a row, nil is returned when they are empty or invalid
+func (c *catalogImport) parseOption(line int, cell func(string) string) *importOption {
+	code := cell(columnOptionCode)
+	name := cell(columnOptionName)
+	quantity := cell(columnQuantity)
+	price := cell(columnPrice)
+	if code == "" && name == "" && quantity == "" && price == "" {
+		return nil
+	}
+
+	failed := len(c.errors)
+	option := &importOption{line: line, code: code, name: name}
+	c.checkText(line, columnOptionCode, code, 100)
+	c.checkText(line, columnOptionName, name, 255)
+
+	if q, err := decimal.NewFromString(quantity); err != nil || !q.IsInteger() || q.IsNegative() || !q.LessThan(decimal.NewFromInt32(1<<31-1)) {
+		c.fail(line, columnQuantity, "must be a whole number of at least 0")
+	} else {
+		option.quantity = int(q.IntPart())
+	}
+	if p, err := decimal.NewFromString(price); err != nil || p.IsNegative() {
+		c.fail(line, columnPrice, "must be a number of at least 0")
+	} else {
+		option.price = p
+	}
+
+	if len(c.errors) > failed {
+		return nil
+	}
+	return option
+}
+
+func (c *catalogImport) checkText(line int, column, value string, maxLength int) {
+	if value == "" {
+		c.fail(line, column, "is required")
+	} else if utf8.RuneCountInString(value) > maxLength {
+		c.fail(line, column, fmt.Sprintf("must not be longer than %d characters", maxLength))
+	}
+}
+
+// compareProduct checks that a later row of a product repeats the product columns of its first row
+func (c *catalogImport) compareProduct(product, row *importProduct) {
+	differs := func(column string) {
+		c.fail(row.line, column, fmt.Sprintf("differs from row %d of product %s", product.line, product.code))
+	}
+	if row.name != product.name {
+		differs(columnProductName)
+	}
+	if c.hasCategory && !equalIDs(row.categoryID, product.categoryID) {
+		differs(columnCategoryID)
+	}
+	if c.hasTags && !slices.Equal(row.tags, product.tags) {
+		differs(columnTags)
+	}
+	if c.hasImg && row.img != product.img {
+		differs(columnImg)
+	}
+}
+
+// planImport matches the products and options of the file with the stored ones and checks them against the
+// database. The matched rows are locked until the transaction ends.
+func (s *ProductServer) planImport(ctx context.Context, tx *gorm.DB, file *catalogImport) error {
+	codes := make([]string, 0, len(file.products))
+	categoryIDs := make([]uuid.UUID, 0)
+	for _, p := range file.products {
+		codes = append(codes, p.code)
+		for _, o := range p.options {
+			o.price = models.RoundMoney(o.price, s.sc.Config.Currency.Base)
+		}
+		if p.categoryID != nil && !slices.Contains(categoryIDs, *p.categoryID) {
+			categoryIDs = append(categoryIDs, *p.categoryID)
+		}
+	}
+
+	products, err := s.sc.ProductRepo.WithTx(tx).FindForUpdate(ctx, map[string]any{
+		"code" + database.CONDITION_IN: codes,
+	})
+	if err != nil {
+		return err
+	}
+	byCode := make(map[string]*models.Product, len(products))
+	productIDs := make([]uuid.UUID, 0, len(products))
+	for i := range products {
+		byCode[products[i].Code] = &products[i]
+		productIDs = append(productIDs, products[i].ID)
+	}
+
+	var options []models.ProductOption
+	tags := map[uuid.UUID][]string{}
+	if len(productIDs) > 0 {
+		options, err = s.sc.ProductOptionRepo.WithTx(tx).FindForUpdate(ctx, map[string]any{
+			"product_id" + database.CONDITION_IN: productIDs,
+		})
+		if err != nil {
+			return err
+		}
+		if file.hasTags {
+			if tags, err = s.productTagNames(ctx, tx, productIDs); err != nil {
+				return err
+			}
+		}
+	}
+	optionsByProduct := make(map[uuid.UUID]map[string]*models.ProductOption, len(products))
+	for i := range options {
+		o := &options[i]
+		byOptionCode := optionsByProduct[o.ProductID]
+		if byOptionCode == nil {
+			byOptionCode = map[string]*models.ProductOption{}
+			optionsByProduct[o.ProductID] = byOptionCode
+		}
+		// an active option wins over an archived one with the same code
+		if current, ok := byOptionCode[o.Code]; !ok || (current.ArchivedAt != nil && o.ArchivedAt == nil) {
+			byOptionCode[o.Code] = o
+		}
+	}
+
+	categories := map[uuid.UUID]bool{}
+	if len(categoryIDs) > 0 {
+		found, err := s.sc.CategoryRepo.WithTx(tx).Find(ctx, map[string]any{
+			"id" + database.CONDITION_IN: categoryIDs,
+		}, []string{"id"}, 0, 0, nil)
+		if err != nil {
+			return err
+		}
+		for _, c := range found {
+			categories[c.ID] = true
+		}
+	}
+
+	for _, p := range file.products {
+		if p.categoryID != nil && !categories[*p.categoryID] {
+			file.fail(p.line, columnCategoryID, "category not found")
+		}
+		p.current = byCode[p.code]
+		if p.current == nil {
+			continue
+		}
+		p.currentTags = tags[p.current.ID]
+		slices.Sort(p.currentTags)
+		for _, o := range p.options {
+			o.current = optionsByProduct[p.current.ID][o.code]
+			if o.current != nil && o.quantity < o.current.Reserved {
+				file.fail(o.line, columnQuantity, fmt.Sprintf("can not be lower than the %d units reserved by open purchase orders", o.current.Reserved))
+			}
+		}
+	}
+	return nil
+}
+
+// productTagNames returns the tag names of the given products
+func (s *ProductServer) productTagNames(ctx context.Context, tx *gorm.DB, productIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	var rows []struct {
+		ProductID uuid.UUID
+		Name      string
+	}
+	err := s.sc.TagRepo.WithTx(tx).GetDB().WithContext(ctx).
+		Table("product_tags").
+		Select("product_tags.product_id, tags.name").
+		Joins("JOIN tags ON tags.id = product_tags.tag_id").
+		Where("product_tags.product_id IN ?", productIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uuid.UUID][]string, len(productIDs))
+	for _, r := range rows {
+		names[r.ProductID] = append(names[r.ProductID], r.Name)
+	}
+	return names, nil
+}
+
+// productChanges returns the product columns the file changes, matched against the current product
+func (c *catalogImport) productChanges(p *importProduct) map[string]any {
+	changes := map[string]any{}
+	if p.name != p.current.Name {
+		changes["name"] = p.name
+	}
+	if c.hasCategory && !equalIDs(p.categoryID, p.current.CategoryID) {
+		changes["category_id"] = p.categoryID
+	}
+	if c.hasImg && p.img != p.current.Img {
+		changes["img"] = p.img
+	}
+	return changes
+}
+
+func (c *catalogImport) tagsChanged(p *importProduct) bool {
+	return c.hasTags && !slices.Equal(p.tags, p.currentTags)
+}
+
+func (o *importOption) changed() bool {
+	return o.name != o.current.Name || o.quantity != o.current.Quantity ||
+		!o.price.Equal(o.current.Price) || o.current.ArchivedAt != nil
+}
+
+// counts reports what applying a planned import changes
+func (c *catalogImport) counts() (products, options ProductImportCounts) {
+	for _, p := range c.products {
+		switch {
+		case p.current == nil:
+			products.Created++
+		case len(c.productChanges(p)) > 0 || c.tagsChanged(p):
+			products.Updated++
+		default:
+			products.Unchanged++
+		}
+		for _, o := range p.options {
+			switch {
+			case o.current == nil:
+				options.Created++
+			case o.changed():
+				options.Updated++
+			default:
+				options.Unchanged++
+			}
+		}
+	}
+	return products, options
+}
+
+// applyImport saves a planned import. Options are updated in place like in PutProductId, options that are
+// not in the file are kept.
+func (s *ProductServer) applyImport(ctx context.Context, tx *gorm.DB, file *catalogImport) error {
+	tagsByName := map[string]models.Tag{}
+	if file.hasTags {
+		var names []string
+		for _, p := range file.products {
+			names = append(names, p.tags...)
+		}
+		tags, err := s.resolveTags(ctx, tx, names)
+		if err != nil {
+			return err
+		}
+		for _, t := range tags {
+			tagsByName[t.Name] = t
+		}
+	}
+	productTags := func(p *importProduct) []models.Tag {
+		tags := make([]models.Tag, 0, len(p.tags))
+		for _, name := range p.tags {
+			tags = append(tags, tagsByName[name])
+		}
+		return tags
+	}
+
+	now := time.Now()
+	for _, p := range file.products {
+		if p.current == nil {
+			if err := s.createImportedProduct(ctx, tx, p, productTags(p), now); err != nil {
+				return err
+			}
+			continue
+		}
+
+		changes := file.productChanges(p)
+		if len(changes) > 0 || file.tagsChanged(p) {
+			changes["updated_at"] = now
+			if err := s.sc.ProductRepo.WithTx(tx).Update(ctx, p.current.ID, changes); err != nil {
+				return err
+			}
+		}
+		if file.tagsChanged(p) {
+			err := s.sc.ProductRepo.WithTx(tx).GetDB().WithContext(ctx).Model(p.current).Association("Tags").Replace(productTags(p))
+			if err != nil {
+				return err
+			}
+		}
+
+		var created, previous, current []models.ProductOption
+		for _, o := range p.options {
+			if o.current == nil {
+				option := models.ProductOption{
+					ID:        uuid.New(),
+					ProductID: p.current.ID,
+					Name:      o.name,
+					Code:      o.code,
+					Quantity:  o.quantity,
+					Price:     o.price,
+					CreatedAt: now,
+					UpdatedAt: now,
+				}
+				created = append(created, option)
+				current = append(current, option)
+				continue
+			}
+			if !o.changed() {
+				continue
+			}
+
+			err := s.sc.ProductOptionRepo.WithTx(tx).Update(ctx, o.current.ID, map[string]any{
+				"name":        o.name,
+				"quantity":    o.quantity,
+				"price":       o.price,
+				"archived_at": nil,
+				"updated_at":  now,
+			})
+			if err != nil {
+				return err
+			}
+			previous = append(previous, *o.current)
+			current = append(current, models.ProductOption{ID: o.current.ID, Quantity: o.quantity})
+		}
+		if len(created) > 0 {
+			if err := s.sc.ProductOptionRepo.WithTx(tx).CreateMany(ctx, created); err != nil {
+				return err
+			}
+		}
+		if err := s.recordManualAdjustments(ctx, tx, p.current.ID, previous, current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createImportedProduct creates a product of the file that does not exist yet, like PostProduct
+func (s *ProductServer) createImportedProduct(ctx context.Context, tx *gorm.DB, p *importProduct, tags []models.Tag, now time.Time) error {
+	options := make([]models.ProductOption, 0, len(p.options))
+	for _, o := range p.options {
+		options = append(options, models.ProductOption{
+			ID:       uuid.New(),
+			Name:     o.name,
+			Code:     o.code,
+			Quantity: o.quantity,
+			Price:    o.price,
+		})
+	}
+
+	product := &models.Product{
+		ID:         uuid.New(),
+		Name:       p.name,
+		Code:       p.code,
+		Img:        p.img,
+		CategoryID: p.categoryID,
+		Options:    options,
+		Tags:       tags,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.sc.ProductRepo.WithTx(tx).Create(ctx, product); err != nil {
+		return err
+	}
+	if err := s.recordManualAdjustments(ctx, tx, product.ID, nil, options); err != nil {
+		return err
+	}
+
+	event, err := outbox.NewEvent(string(websocket.EventProductCreated), product, uuid.Nil, websocket.ProductTopic(product.ID))
+	if err != nil {
+		return err
+	}
+	return s.sc.OutboxEventRepo.WithTx(tx).Create(ctx, event)
+}
+
+// unescapeCell removes the quote the export puts in front of CSV cells that spreadsheets would read as a formula
+func unescapeCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune("=+-@", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func equalIDs(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,215 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LeHNam/wao-api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OptionUpdateError is returned when the requested options can not be applied to the product
+type OptionUpdateError struct {
+	Message string
+}
+
+func (e *OptionUpdateError) Error() string {
+	return e.Message
+}
+
+// optionChanges is the outcome of syncing the options of a product
+type optionChanges struct {
+	created []models.ProductOption
+	updated []models.ProductOption
+	removed []RemovedProductOption
+	// active are the options left on the product, in request order
+	active []models.ProductOption
+}
+
+// syncOptions applies the requested options to a product. Options are matched by id, or by code when no id
+// is given, and updated in place so purchase order items keep pointing at them. Options that are left out
+// are deleted, or archived once a purchase order references them so order items and the stock ledger keep
+// pointing at an existing option.
+func (s *ProductServer) syncOptions(ctx context.Context, tx *gorm.DB, productID uuid.UUID, requested []ProductOptionUpdateRequest) (*optionChanges, error) {
+	existing, err := s.sc.ProductOptionRepo.WithTx(tx).FindForUpdate(ctx, map[string]any{"product_id": productID})
+	if err != nil {
+		return nil, err
+	}
+
+	// options listed by id are taken first, so a code given up by one of them in this request can be reused
+	claimed := make(map[uuid.UUID]bool, len(requested))
+	for _, o := range requested {
+		if o.Id != nil {
+			claimed[*o.Id] = true
+		}
+	}
+
+	byID := make(map[uuid.UUID]*models.ProductOption, len(existing))
+	byCode := make(map[string]*models.ProductOption, len(existing))
+	for i := range existing {
+		o := &existing[i]
+		byID[o.ID] = o
+		if claimed[o.ID] {
+			continue
+		}
+		// an active option wins over an archived one with the same code
+		if current, ok := byCode[o.Code]; !ok || (current.ArchivedAt != nil && o.ArchivedAt == nil) {
+			byCode[o.Code] = o
+		}
+	}
+
+	changes := &optionChanges{}
+	var previous, current []models.ProductOption
+	matched := make(map[uuid.UUID]bool, len(requested))
+	codes := make(map[string]bool, len(requested))
+	now := time.Now()
+	for _, o := range requested {
+		if codes[o.Code] {
+			return nil, &OptionUpdateError{Message: fmt.Sprintf("option code %s is used more than once", o.Code)}
+		}
+		codes[o.Code] = true
+
+		var option *models.ProductOption
+		if o.Id != nil {
+			option = byID[*o.Id]
+			if option == nil {
+				return nil, &OptionUpdateError{Message: fmt.Sprintf("option %s does not belong to this product", o.Id.String())}
+			}
+		} else {
+			option = byCode[o.Code]
+		}
+
+		price := models.RoundMoney(o.Price, s.sc.Config.Currency.Base)
+		if option == nil {
+			created := models.ProductOption{
+				ID:        uuid.New(),
+				ProductID: productID,
+				Name:      o.Name,
+				Code:      o.Code,
+				Quantity:  o.Quantity,
+				Price:     price,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			changes.created = append(changes.created, created)
+			changes.active = append(changes.active, created)
+			current = append(current, created)
+			continue
+		}
+
+		if matched[option.ID] {
+			return nil, &OptionUpdateError{Message: fmt.Sprintf("option %s is listed more than once", option.ID.String())}
+		}
+		matched[option.ID] = true
+		if o.Quantity < option.Reserved {
+			return nil, &OptionUpdateError{Message: fmt.Sprintf("quantity of option %s can not be lower than the %d units reserved by open purchase orders", o.Code, option.Reserved)}
+		}
+
+		if option.Name == o.Name && option.Code == o.Code && option.Quantity == o.Quantity &&
+			option.Price.Equal(price) && option.ArchivedAt == nil {
+			changes.active = append(changes.active, *option)
+			continue
+		}
+
+		err = s.sc.ProductOptionRepo.WithTx(tx).Update(ctx, option.ID, map[string]any{
+			"name":        o.Name,
+			"code":        o.Code,
+			"quantity":    o.Quantity,
+			"price":       price,
+			"archived_at": nil,
+			"updated_at":  now,
+		})
+		if err != nil {
+			return nil, err
+		}
+		previous = append(previous, *option)
+
+		updated := *option
+		updated.Name = o.Name
+		updated.Code = o.Code
+		updated.Quantity = o.Quantity
+		updated.Price = price
+		updated.ArchivedAt = nil
+		updated.UpdatedAt = now
+		changes.updated = append(changes.updated, updated)
+		changes.active = append(changes.active, updated)
+		current = append(current, updated)
+	}
+
+	if len(changes.created) > 0 {
+		if err = s.sc.ProductOptionRepo.WithTx(tx).CreateMany(ctx, changes.created); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range existing {
+		o := &existing[i]
+		if matched[o.ID] || o.ArchivedAt != nil {
+			continue
+		}
+
+		referenced, err := s.referencedByOrders(ctx, tx, o.ID)
+		if err != nil {
+			return nil, err
+		}
+		if referenced {
+			err = s.sc.ProductOptionRepo.WithTx(tx).Update(ctx, o.ID, map[string]any{
+				"archived_at": now,
+				"updated_at":  now,
+			})
+		} else {
+			// never ordered, its ledger only holds its opening balance and manual adjustments and goes with it
+			err = s.sc.StockMovementRepo.WithTx(tx).DeleteWhere(ctx, map[string]any{"product_option_id": o.ID})
+			if err == nil {
+				err = s.sc.ProductOptionRepo.WithTx(tx).Delete(ctx, o.ID)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		changes.removed = append(changes.removed, RemovedProductOption{
+			Id:       o.ID.String(),
+			Code:     o.Code,
+			Archived: referenced,
+		})
+	}
+
+	if err = s.recordManualAdjustments(ctx, tx, productID, previous, current); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// referencedByOrders reports whether an option is part of any purchase order, whatever its status.
+// Soft deleted order items still point at the option, so they count too.
+func (s *ProductServer) referencedByOrders(ctx context.Context, tx *gorm.DB, optionID uuid.UUID) (bool, error) {
+	var count int64
+	err := s.sc.PurchaseOrderItemRepo.WithTx(tx).GetDB().WithContext(ctx).Unscoped().
+		Model(&models.PurchaseOrderItem{}).
+		Where("product_option_id = ?", optionID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func toProductOption(o models.ProductOption) ProductOption {
+	return ProductOption{
+		Id:       o.ID.String(),
+		Name:     o.Name,
+		Code:     o.Code,
+		Quantity: o.Quantity,
+		Price:    o.Price,
+	}
+}
+
+func toProductOptions(options []models.ProductOption) []ProductOption {
+	items := make([]ProductOption, 0, len(options))
+	for _, o := range options {
+		items = append(items, toProductOption(o))
+	}
+	return items
+}
@@ -0,0 +1,98 @@
+package product
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// sortRelevance orders search results by how well they match, best first
+const sortRelevance = "relevance"
+
+// productSearch matches the search text kept by the database for every product (see migrateSearch).
+// A product matches when it contains all words as word prefixes, when a word is similar enough to one of
+// its words to tolerate typos, or when it contains the text as is. Case and accents are ignored.
+type productSearch struct {
+	text string
+	// query is the tsquery of the words, empty when the text has no letters or digits
+	query string
+}
+
+func newProductSearch(text string) *productSearch {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return nil
+	}
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, word+":*")
+	}
+	return &productSearch{
+		text:  text,
+		query: strings.Join(terms, " & "),
+	}
+}
+
+func (p *productSearch) condition() (string, []any) {
+	like := "%" + database.EscapeLike(p.text) + "%"
+	if p.query == "" {
+		return `products.search_text LIKE unaccent(?) ESCAPE '\'`, []any{like}
+	}
+	return `products.search_vector @@ to_tsquery('simple', unaccent(?)) OR unaccent(?) <% products.search_text OR products.search_text LIKE unaccent(?) ESCAPE '\'`,
+		[]any{p.query, p.text, like}
+}
+
+func (p *productSearch) rank() (string, []any) {
+	if p.query == "" {
+		return "word_similarity(unaccent(?), products.search_text) DESC", []any{p.text}
+	}
+	return "ts_rank(products.search_vector, to_tsquery('simple', unaccent(?))) + word_similarity(unaccent(?), products.search_text) DESC",
+		[]any{p.query, p.text}
+}
+
+// matching selects the ids of the matching products, to be used as a subquery
+func (p *productSearch) matching(db *gorm.DB) *gorm.DB {
+	condition, args := p.condition()
+	return db.Model(&models.Product{}).Select("products.id").Where(condition, args...)
+}
+
+// rankedProductIDs returns a page of the ids of the products matching the conditions, best match first
+func (s *ProductServer) rankedProductIDs(ctx context.Context, search *productSearch, cond map[string]any, limit, offset int) ([]uuid.UUID, error) {
+	rank, args := search.rank()
+	query := s.sc.ProductRepo.GetDB().WithContext(ctx).Model(&models.Product{})
+	query = database.ApplyConditions(query, cond).
+		Order(gorm.Expr(rank, args...)).
+		Order("products.created_at DESC").
+		Order("products.id")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	var ids []uuid.UUID
+	err := query.Pluck("products.id", &ids).Error
+	return ids, err
+}
+
+// sortProducts puts products in the order of ids
+func sortProducts(products []models.Product, ids []uuid.UUID) {
+	position := make(map[uuid.UUID]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	sort.Slice(products, func(i, j int) bool {
+		return position[products[i].ID] < position[products[j].ID]
+	})
+}
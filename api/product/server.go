@@ -10,21 +10,53 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
 	"github.com/oapi-codegen/runtime"
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/shopspring/decimal"
 )
 
 const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for ProductImageUploadPrimary.
+const (
+	ProductImageUploadPrimaryFalse ProductImageUploadPrimary = "false"
+	ProductImageUploadPrimaryTrue  ProductImageUploadPrimary = "true"
+)
+
+// Defines values for ProductImportUploadDryRun.
+const (
+	ProductImportUploadDryRunFalse ProductImportUploadDryRun = "false"
+	ProductImportUploadDryRunTrue  ProductImportUploadDryRun = "true"
+)
+
+// Defines values for StockMovementReason.
+const (
+	MANUALADJUSTMENT StockMovementReason = "MANUAL_ADJUSTMENT"
+	OPENINGBALANCE   StockMovementReason = "OPENING_BALANCE"
+	POFULFILMENT     StockMovementReason = "PO_FULFILMENT"
+	PORESERVATION    StockMovementReason = "PO_RESERVATION"
+	RETURN           StockMovementReason = "RETURN"
+)
+
+// Defines values for GetProductExportParamsFormat.
+const (
+	Csv  GetProductExportParamsFormat = "csv"
+	Xlsx GetProductExportParamsFormat = "xlsx"
+)
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -33,36 +65,147 @@ type ErrorResponse struct {
 
 // Product defines model for Product.
 type Product struct {
-	Code    string          `json:"code"`
-	Id      string          `json:"id"`
-	Img     string          `json:"img"`
-	Name    string          `json:"name"`
-	Options []ProductOption `json:"options"`
+	CategoryId *string         `json:"category_id,omitempty"`
+	Code       string          `json:"code"`
+	Id         string          `json:"id"`
+	Images     *[]ProductImage `json:"images,omitempty"`
+	Img        string          `json:"img"`
+	Name       string          `json:"name"`
+	Options    []ProductOption `json:"options"`
+	Tags       *[]string       `json:"tags,omitempty"`
 }
 
 // ProductCreateRequest defines model for ProductCreateRequest.
 type ProductCreateRequest struct {
-	Code    string                       `json:"code"`
-	Img     string                       `json:"img"`
-	Name    string                       `json:"name"`
-	Options []ProductOptionCreateRequest `json:"options"`
+	CategoryId *openapi_types.UUID          `json:"category_id,omitempty"`
+	Code       string                       `json:"code"`
+	Img        string                       `json:"img"`
+	Name       string                       `json:"name"`
+	Options    []ProductOptionCreateRequest `json:"options"`
+	Tags       *[]string                    `json:"tags,omitempty"`
+}
+
+// ProductImage defines model for ProductImage.
+type ProductImage struct {
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	Height      int       `json:"height"`
+	Id          string    `json:"id"`
+	Position    int       `json:"position"`
+	Primary     bool      `json:"primary"`
+
+	// Size File size in bytes
+	Size       int64                   `json:"size"`
+	Thumbnails []ProductImageThumbnail `json:"thumbnails"`
+	Url        string                  `json:"url"`
+	Width      int                     `json:"width"`
+}
+
+// ProductImageList defines model for ProductImageList.
+type ProductImageList struct {
+	Items []ProductImage `json:"items"`
+}
+
+// ProductImageThumbnail defines model for ProductImageThumbnail.
+type ProductImageThumbnail struct {
+	Height int `json:"height"`
+
+	// Size The longest side of the thumbnail is at most this many pixels
+	Size  int    `json:"size"`
+	Url   string `json:"url"`
+	Width int    `json:"width"`
+}
+
+// ProductImageUpdateRequest defines model for ProductImageUpdateRequest.
+type ProductImageUpdateRequest struct {
+	// Position Zero based position of the image, the other images shift to make room
+	Position *int `json:"position,omitempty"`
+
+	// Primary true makes the image the primary image of the product
+	Primary *bool `json:"primary,omitempty"`
+}
+
+// ProductImageUpload defines model for ProductImageUpload.
+type ProductImageUpload struct {
+	File openapi_types.File `json:"file"`
+
+	// Primary Make the uploaded image the primary image of the product
+	Primary *ProductImageUploadPrimary `json:"primary,omitempty"`
+}
+
+// ProductImageUploadPrimary Make the uploaded image the primary image of the product
+type ProductImageUploadPrimary string
+
+// ProductImportCounts defines model for ProductImportCounts.
+type ProductImportCounts struct {
+	Created   int `json:"created"`
+	Unchanged int `json:"unchanged"`
+	Updated   int `json:"updated"`
+}
+
+// ProductImportError defines model for ProductImportError.
+type ProductImportError struct {
+	Column  *string `json:"column,omitempty"`
+	Message string  `json:"message"`
+
+	// Row Row number in the file, the header is row 1
+	Row int `json:"row"`
+}
+
+// ProductImportResult defines model for ProductImportResult.
+type ProductImportResult struct {
+	DryRun   bool                 `json:"dry_run"`
+	Errors   []ProductImportError `json:"errors"`
+	Options  ProductImportCounts  `json:"options"`
+	Products ProductImportCounts  `json:"products"`
+
+	// Rows Number of data rows in the file
+	Rows int `json:"rows"`
+}
+
+// ProductImportUpload Columns are matched by their header: product_code, product_name, option_code, option_name, quantity and price are required, category_id, tags and img are optional. tags is a comma separated list. Optional columns that are left out of the file keep the current values, empty cells clear them. Product columns must be the same on every row of a product. A row with empty option columns only imports the product.
+type ProductImportUpload struct {
+	// DryRun Validate and report the changes without saving them
+	DryRun *ProductImportUploadDryRun `json:"dry_run,omitempty"`
+
+	// File CSV or XLSX file, the format is detected from the content. Only the first sheet of an XLSX file is read.
+	File openapi_types.File `json:"file"`
 }
 
+// ProductImportUploadDryRun Validate and report the changes without saving them
+type ProductImportUploadDryRun string
+
 // ProductOption defines model for ProductOption.
 type ProductOption struct {
-	Code     string  `json:"code"`
-	Id       string  `json:"id"`
-	Name     string  `json:"name"`
-	Price    float32 `json:"price"`
-	Quantity int     `json:"quantity"`
+	Code     string          `json:"code"`
+	Id       string          `json:"id"`
+	Name     string          `json:"name"`
+	Price    decimal.Decimal `json:"price"`
+	Quantity int             `json:"quantity"`
+}
+
+// ProductOptionChanges defines model for ProductOptionChanges.
+type ProductOptionChanges struct {
+	Created []ProductOption        `json:"created"`
+	Removed []RemovedProductOption `json:"removed"`
+	Updated []ProductOption        `json:"updated"`
 }
 
 // ProductOptionCreateRequest defines model for ProductOptionCreateRequest.
 type ProductOptionCreateRequest struct {
-	Code     string  `json:"code"`
-	Name     string  `json:"name"`
-	Price    float32 `json:"price"`
-	Quantity int     `json:"quantity"`
+	Code     string          `json:"code"`
+	Name     string          `json:"name"`
+	Price    decimal.Decimal `json:"price"`
+	Quantity int             `json:"quantity"`
+}
+
+// ProductOptionUpdateRequest defines model for ProductOptionUpdateRequest.
+type ProductOptionUpdateRequest struct {
+	Code     string              `json:"code"`
+	Id       *openapi_types.UUID `json:"id,omitempty"`
+	Name     string              `json:"name"`
+	Price    decimal.Decimal     `json:"price"`
+	Quantity int                 `json:"quantity"`
 }
 
 // ProductPaginateResponseData defines model for ProductPaginateResponseData.
@@ -82,26 +225,125 @@ type ProductResponse struct {
 
 // ProductUpdateRequest defines model for ProductUpdateRequest.
 type ProductUpdateRequest struct {
-	Code    *string                       `json:"code,omitempty"`
-	Img     *string                       `json:"img,omitempty"`
-	Name    *string                       `json:"name,omitempty"`
-	Options *[]ProductOptionCreateRequest `json:"options,omitempty"`
+	// CategoryId Moves the product to this category when given, the category is kept when omitted
+	CategoryId *openapi_types.UUID `json:"category_id,omitempty"`
+	Code       *string             `json:"code,omitempty"`
+	Img        *string             `json:"img,omitempty"`
+	Name       *string             `json:"name,omitempty"`
+
+	// Options The full list of options of the product. Options are matched by id, or by code when no id is given; a code only matches an option that no other entry claims by id. Unmatched options are created, options left out are deleted, or archived when a purchase order references them.
+	Options *[]ProductOptionUpdateRequest `json:"options,omitempty"`
+
+	// Tags Replaces the tags of the product when given
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// ProductUpdateResponse defines model for ProductUpdateResponse.
+type ProductUpdateResponse struct {
+	Data    Product              `json:"data"`
+	Message *string              `json:"message,omitempty"`
+	Options ProductOptionChanges `json:"options"`
+}
+
+// RemovedProductOption defines model for RemovedProductOption.
+type RemovedProductOption struct {
+	// Archived true when the option is referenced by a purchase order and was archived instead of deleted
+	Archived bool   `json:"archived"`
+	Code     string `json:"code"`
+	Id       string `json:"id"`
+}
+
+// StockMovement defines model for StockMovement.
+type StockMovement struct {
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy *string   `json:"created_by,omitempty"`
+
+	// Delta Change of the on-hand quantity
+	Delta           int                 `json:"delta"`
+	Id              string              `json:"id"`
+	ProductOptionId string              `json:"product_option_id"`
+	Reason          StockMovementReason `json:"reason"`
+
+	// ReservedDelta Change of the quantity reserved by open purchase orders
+	ReservedDelta int `json:"reserved_delta"`
+
+	// SourceId ID of the product or purchase order that caused the movement
+	SourceId *string `json:"source_id,omitempty"`
+}
+
+// StockMovementReason defines model for StockMovement.Reason.
+type StockMovementReason string
+
+// StockMovementPaginateResponseData defines model for StockMovementPaginateResponseData.
+type StockMovementPaginateResponseData struct {
+	Items []StockMovement `json:"items"`
+	Limit int             `json:"limit"`
+	Page  int             `json:"page"`
+	Pages int             `json:"pages"`
+	Total int             `json:"total"`
+}
+
+// StockRebuildResponse defines model for StockRebuildResponse.
+type StockRebuildResponse struct {
+	PreviousQuantity int `json:"previous_quantity"`
+	PreviousReserved int `json:"previous_reserved"`
+	Quantity         int `json:"quantity"`
+	Reserved         int `json:"reserved"`
 }
 
 // GetProductParams defines parameters for GetProduct.
 type GetProductParams struct {
-	Page   int     `form:"page" json:"page"`
-	Limit  int     `form:"limit" json:"limit"`
-	Sort   *string `form:"sort,omitempty" json:"sort,omitempty"`
+	Page  int `form:"page" json:"page"`
+	Limit int `form:"limit" json:"limit"`
+
+	// Sort Comma separated fields, prefixed with - for descending order. relevance orders search results by how well they match and is ignored without search.
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Search Matches product names and codes and the names and codes of their options. Words match as prefixes in any order, small typos are tolerated and case and accents are ignored.
 	Search *string `form:"search,omitempty" json:"search,omitempty"`
+
+	// CategoryId Only products in this category or one of its subcategories
+	CategoryId *string `form:"category_id,omitempty" json:"category_id,omitempty"`
+
+	// Tag Only products with this tag
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// PostProductParams defines parameters for PostProduct.
+type PostProductParams struct {
+	// IdempotencyKey Retries with the same key replay the first response instead of creating another product
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// GetProductExportParams defines parameters for GetProductExport.
+type GetProductExportParams struct {
+	Format *GetProductExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetProductExportParamsFormat defines parameters for GetProductExport.
+type GetProductExportParamsFormat string
+
+// GetProductIdOptionsOptionIdStockMovementsParams defines parameters for GetProductIdOptionsOptionIdStockMovements.
+type GetProductIdOptionsOptionIdStockMovementsParams struct {
+	Page  int `form:"page" json:"page"`
+	Limit int `form:"limit" json:"limit"`
 }
 
 // PostProductJSONRequestBody defines body for PostProduct for application/json ContentType.
 type PostProductJSONRequestBody = ProductCreateRequest
 
+// PostProductImportMultipartRequestBody defines body for PostProductImport for multipart/form-data ContentType.
+type PostProductImportMultipartRequestBody = ProductImportUpload
+
 // PutProductIdJSONRequestBody defines body for PutProductId for application/json ContentType.
 type PutProductIdJSONRequestBody = ProductUpdateRequest
 
+// PostProductIdImagesMultipartRequestBody defines body for PostProductIdImages for multipart/form-data ContentType.
+type PostProductIdImagesMultipartRequestBody = ProductImageUpload
+
+// PatchProductIdImagesImageIdJSONRequestBody defines body for PatchProductIdImagesImageId for application/json ContentType.
+type PatchProductIdImagesImageIdJSONRequestBody = ProductImageUpdateRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// List all products
@@ -109,7 +351,13 @@ type ServerInterface interface {
 	GetProduct(c *gin.Context, params GetProductParams)
 	// Create new product
 	// (POST /product)
-	PostProduct(c *gin.Context)
+	PostProduct(c *gin.Context, params PostProductParams)
+	// Export the catalogue as a CSV or XLSX file
+	// (GET /product/export)
+	GetProductExport(c *gin.Context, params GetProductExportParams)
+	// Import products from a CSV or XLSX file
+	// (POST /product/import)
+	PostProductImport(c *gin.Context)
 	// Delete product by ID
 	// (DELETE /product/{id})
 	DeleteProductId(c *gin.Context, id string)
@@ -119,6 +367,24 @@ type ServerInterface interface {
 	// Update product by ID (including options)
 	// (PUT /product/{id})
 	PutProductId(c *gin.Context, id string)
+	// List the images of a product
+	// (GET /product/{id}/images)
+	GetProductIdImages(c *gin.Context, id string)
+	// Upload a product image
+	// (POST /product/{id}/images)
+	PostProductIdImages(c *gin.Context, id string)
+	// Delete a product image
+	// (DELETE /product/{id}/images/{imageId})
+	DeleteProductIdImagesImageId(c *gin.Context, id string, imageId string)
+	// Reorder a product image or make it the primary image
+	// (PATCH /product/{id}/images/{imageId})
+	PatchProductIdImagesImageId(c *gin.Context, id string, imageId string)
+	// List stock movements of a product option
+	// (GET /product/{id}/options/{optionId}/stock-movements)
+	GetProductIdOptionsOptionIdStockMovements(c *gin.Context, id string, optionId string, params GetProductIdOptionsOptionIdStockMovementsParams)
+	// Rebuild the stock of a product option from its stock movements
+	// (POST /product/{id}/options/{optionId}/stock/rebuild)
+	PostProductIdOptionsOptionIdStockRebuild(c *gin.Context, id string, optionId string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -186,6 +452,22 @@ func (siw *ServerInterfaceWrapper) GetProduct(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "category_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category_id", c.Request.URL.Query(), &params.CategoryId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter category_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", c.Request.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tag: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -199,6 +481,75 @@ func (siw *ServerInterfaceWrapper) GetProduct(c *gin.Context) {
 // PostProduct operation middleware
 func (siw *ServerInterfaceWrapper) PostProduct(c *gin.Context) {
 
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostProductParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostProduct(c, params)
+}
+
+// GetProductExport operation middleware
+func (siw *ServerInterfaceWrapper) GetProductExport(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductExportParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProductExport(c, params)
+}
+
+// PostProductImport operation middleware
+func (siw *ServerInterfaceWrapper) PostProductImport(c *gin.Context) {
+
 	c.Set(BearerAuthScopes, []string{})
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -208,7 +559,7 @@ func (siw *ServerInterfaceWrapper) PostProduct(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostProduct(c)
+	siw.Handler.PostProductImport(c)
 }
 
 // DeleteProductId operation middleware
@@ -289,170 +640,736 @@ func (siw *ServerInterfaceWrapper) PutProductId(c *gin.Context) {
 	siw.Handler.PutProductId(c, id)
 }
 
-// GinServerOptions provides options for the Gin server.
-type GinServerOptions struct {
-	BaseURL      string
-	Middlewares  []MiddlewareFunc
-	ErrorHandler func(*gin.Context, error, int)
-}
+// GetProductIdImages operation middleware
+func (siw *ServerInterfaceWrapper) GetProductIdImages(c *gin.Context) {
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router gin.IRouter, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, GinServerOptions{})
-}
+	var err error
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
-	errorHandler := options.ErrorHandler
-	if errorHandler == nil {
-		errorHandler = func(c *gin.Context, err error, statusCode int) {
-			c.JSON(statusCode, gin.H{"msg": err.Error()})
-		}
-	}
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandler:       errorHandler,
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	router.GET(options.BaseURL+"/product", wrapper.GetProduct)
-	router.POST(options.BaseURL+"/product", wrapper.PostProduct)
-	router.DELETE(options.BaseURL+"/product/:id", wrapper.DeleteProductId)
-	router.GET(options.BaseURL+"/product/:id", wrapper.GetProductId)
-	router.PUT(options.BaseURL+"/product/:id", wrapper.PutProductId)
-}
+	c.Set(BearerAuthScopes, []string{})
 
-type GetProductRequestObject struct {
-	Params GetProductParams
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type GetProductResponseObject interface {
-	VisitGetProductResponse(w http.ResponseWriter) error
+	siw.Handler.GetProductIdImages(c, id)
 }
 
-type GetProduct200JSONResponse ProductPaginateResponseData
-
-func (response GetProduct200JSONResponse) VisitGetProductResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// PostProductIdImages operation middleware
+func (siw *ServerInterfaceWrapper) PostProductIdImages(c *gin.Context) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type GetProduct400JSONResponse ErrorResponse
+	// ------------- Path parameter "id" -------------
+	var id string
 
-func (response GetProduct400JSONResponse) VisitGetProductResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	c.Set(BearerAuthScopes, []string{})
 
-type PostProductRequestObject struct {
-	Body *PostProductJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type PostProductResponseObject interface {
-	VisitPostProductResponse(w http.ResponseWriter) error
+	siw.Handler.PostProductIdImages(c, id)
 }
 
-type PostProduct201JSONResponse ProductResponse
+// DeleteProductIdImagesImageId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProductIdImagesImageId(c *gin.Context) {
 
-func (response PostProduct201JSONResponse) VisitPostProductResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "id" -------------
+	var id string
 
-type PostProduct400JSONResponse ErrorResponse
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
 
-func (response PostProduct400JSONResponse) VisitPostProductResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "imageId", c.Param("imageId"), &imageId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter imageId: %w", err), http.StatusBadRequest)
+		return
+	}
 
-type DeleteProductIdRequestObject struct {
-	Id string `json:"id"`
-}
+	c.Set(BearerAuthScopes, []string{})
 
-type DeleteProductIdResponseObject interface {
-	VisitDeleteProductIdResponse(w http.ResponseWriter) error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-type DeleteProductId204Response struct {
+	siw.Handler.DeleteProductIdImagesImageId(c, id, imageId)
 }
 
-func (response DeleteProductId204Response) VisitDeleteProductIdResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+// PatchProductIdImagesImageId operation middleware
+func (siw *ServerInterfaceWrapper) PatchProductIdImagesImageId(c *gin.Context) {
 
-type DeleteProductId404JSONResponse ErrorResponse
+	var err error
 
-func (response DeleteProductId404JSONResponse) VisitDeleteProductIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "imageId" -------------
+	var imageId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "imageId", c.Param("imageId"), &imageId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter imageId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchProductIdImagesImageId(c, id, imageId)
+}
+
+// GetProductIdOptionsOptionIdStockMovements operation middleware
+func (siw *ServerInterfaceWrapper) GetProductIdOptionsOptionIdStockMovements(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "optionId" -------------
+	var optionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "optionId", c.Param("optionId"), &optionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter optionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductIdOptionsOptionIdStockMovementsParams
+
+	// ------------- Required query parameter "page" -------------
+
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "limit" -------------
+
+	if paramValue := c.Query("limit"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument limit is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProductIdOptionsOptionIdStockMovements(c, id, optionId, params)
+}
+
+// PostProductIdOptionsOptionIdStockRebuild operation middleware
+func (siw *ServerInterfaceWrapper) PostProductIdOptionsOptionIdStockRebuild(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "optionId" -------------
+	var optionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "optionId", c.Param("optionId"), &optionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter optionId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostProductIdOptionsOptionIdStockRebuild(c, id, optionId)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/product", wrapper.GetProduct)
+	router.POST(options.BaseURL+"/product", wrapper.PostProduct)
+	router.GET(options.BaseURL+"/product/export", wrapper.GetProductExport)
+	router.POST(options.BaseURL+"/product/import", wrapper.PostProductImport)
+	router.DELETE(options.BaseURL+"/product/:id", wrapper.DeleteProductId)
+	router.GET(options.BaseURL+"/product/:id", wrapper.GetProductId)
+	router.PUT(options.BaseURL+"/product/:id", wrapper.PutProductId)
+	router.GET(options.BaseURL+"/product/:id/images", wrapper.GetProductIdImages)
+	router.POST(options.BaseURL+"/product/:id/images", wrapper.PostProductIdImages)
+	router.DELETE(options.BaseURL+"/product/:id/images/:imageId", wrapper.DeleteProductIdImagesImageId)
+	router.PATCH(options.BaseURL+"/product/:id/images/:imageId", wrapper.PatchProductIdImagesImageId)
+	router.GET(options.BaseURL+"/product/:id/options/:optionId/stock-movements", wrapper.GetProductIdOptionsOptionIdStockMovements)
+	router.POST(options.BaseURL+"/product/:id/options/:optionId/stock/rebuild", wrapper.PostProductIdOptionsOptionIdStockRebuild)
+}
+
+type GetProductRequestObject struct {
+	Params GetProductParams
+}
+
+type GetProductResponseObject interface {
+	VisitGetProductResponse(w http.ResponseWriter) error
+}
+
+type GetProduct200JSONResponse ProductPaginateResponseData
+
+func (response GetProduct200JSONResponse) VisitGetProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProduct400JSONResponse ErrorResponse
+
+func (response GetProduct400JSONResponse) VisitGetProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductRequestObject struct {
+	Params PostProductParams
+	Body   *PostProductJSONRequestBody
+}
+
+type PostProductResponseObject interface {
+	VisitPostProductResponse(w http.ResponseWriter) error
+}
+
+type PostProduct201JSONResponse ProductResponse
+
+func (response PostProduct201JSONResponse) VisitPostProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProduct400JSONResponse ErrorResponse
+
+func (response PostProduct400JSONResponse) VisitPostProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProduct409JSONResponse ErrorResponse
+
+func (response PostProduct409JSONResponse) VisitPostProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProduct422JSONResponse ErrorResponse
+
+func (response PostProduct422JSONResponse) VisitPostProductResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProductExportRequestObject struct {
+	Params GetProductExportParams
+}
+
+type GetProductExportResponseObject interface {
+	VisitGetProductExportResponse(w http.ResponseWriter) error
+}
+
+type GetProductExport200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetProductExport200ApplicationvndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse struct {
+	Body          io.Reader
+	Headers       GetProductExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetProductExport200ApplicationvndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse) VisitGetProductExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetProductExport200TextcsvResponse struct {
+	Body          io.Reader
+	Headers       GetProductExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetProductExport200TextcsvResponse) VisitGetProductExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type PostProductImportRequestObject struct {
+	Body *multipart.Reader
+}
+
+type PostProductImportResponseObject interface {
+	VisitPostProductImportResponse(w http.ResponseWriter) error
+}
+
+type PostProductImport200JSONResponse ProductImportResult
+
+func (response PostProductImport200JSONResponse) VisitPostProductImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductImport400JSONResponse ErrorResponse
+
+func (response PostProductImport400JSONResponse) VisitPostProductImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductImport413JSONResponse ErrorResponse
+
+func (response PostProductImport413JSONResponse) VisitPostProductImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductImport422JSONResponse ProductImportResult
+
+func (response PostProductImport422JSONResponse) VisitPostProductImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProductIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DeleteProductIdResponseObject interface {
+	VisitDeleteProductIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteProductId204Response struct {
+}
+
+func (response DeleteProductId204Response) VisitDeleteProductIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteProductId404JSONResponse ErrorResponse
+
+func (response DeleteProductId404JSONResponse) VisitDeleteProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProductIdRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetProductIdResponseObject interface {
+	VisitGetProductIdResponse(w http.ResponseWriter) error
+}
+
+type GetProductId200JSONResponse ProductResponse
+
+func (response GetProductId200JSONResponse) VisitGetProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProductId404JSONResponse ErrorResponse
+
+func (response GetProductId404JSONResponse) VisitGetProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutProductIdRequestObject struct {
+	Id   string `json:"id"`
+	Body *PutProductIdJSONRequestBody
+}
+
+type PutProductIdResponseObject interface {
+	VisitPutProductIdResponse(w http.ResponseWriter) error
+}
+
+type PutProductId200JSONResponse ProductUpdateResponse
+
+func (response PutProductId200JSONResponse) VisitPutProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutProductId400JSONResponse ErrorResponse
+
+func (response PutProductId400JSONResponse) VisitPutProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutProductId404JSONResponse ErrorResponse
+
+func (response PutProductId404JSONResponse) VisitPutProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProductIdImagesRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetProductIdImagesResponseObject interface {
+	VisitGetProductIdImagesResponse(w http.ResponseWriter) error
+}
+
+type GetProductIdImages200JSONResponse ProductImageList
+
+func (response GetProductIdImages200JSONResponse) VisitGetProductIdImagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProductIdImages404JSONResponse ErrorResponse
+
+func (response GetProductIdImages404JSONResponse) VisitGetProductIdImagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductIdImagesRequestObject struct {
+	Id   string `json:"id"`
+	Body *multipart.Reader
+}
+
+type PostProductIdImagesResponseObject interface {
+	VisitPostProductIdImagesResponse(w http.ResponseWriter) error
+}
+
+type PostProductIdImages201JSONResponse ProductImage
+
+func (response PostProductIdImages201JSONResponse) VisitPostProductIdImagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductIdImages400JSONResponse ErrorResponse
+
+func (response PostProductIdImages400JSONResponse) VisitPostProductIdImagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductIdImages404JSONResponse ErrorResponse
+
+func (response PostProductIdImages404JSONResponse) VisitPostProductIdImagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetProductIdRequestObject struct {
-	Id string `json:"id"`
+type PostProductIdImages413JSONResponse ErrorResponse
+
+func (response PostProductIdImages413JSONResponse) VisitPostProductIdImagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(413)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetProductIdResponseObject interface {
-	VisitGetProductIdResponse(w http.ResponseWriter) error
+type DeleteProductIdImagesImageIdRequestObject struct {
+	Id      string `json:"id"`
+	ImageId string `json:"imageId"`
 }
 
-type GetProductId200JSONResponse ProductResponse
+type DeleteProductIdImagesImageIdResponseObject interface {
+	VisitDeleteProductIdImagesImageIdResponse(w http.ResponseWriter) error
+}
 
-func (response GetProductId200JSONResponse) VisitGetProductIdResponse(w http.ResponseWriter) error {
+type DeleteProductIdImagesImageId204Response struct {
+}
+
+func (response DeleteProductIdImagesImageId204Response) VisitDeleteProductIdImagesImageIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteProductIdImagesImageId404JSONResponse ErrorResponse
+
+func (response DeleteProductIdImagesImageId404JSONResponse) VisitDeleteProductIdImagesImageIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProductIdImagesImageIdRequestObject struct {
+	Id      string `json:"id"`
+	ImageId string `json:"imageId"`
+	Body    *PatchProductIdImagesImageIdJSONRequestBody
+}
+
+type PatchProductIdImagesImageIdResponseObject interface {
+	VisitPatchProductIdImagesImageIdResponse(w http.ResponseWriter) error
+}
+
+type PatchProductIdImagesImageId200JSONResponse ProductImage
+
+func (response PatchProductIdImagesImageId200JSONResponse) VisitPatchProductIdImagesImageIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetProductId404JSONResponse ErrorResponse
+type PatchProductIdImagesImageId404JSONResponse ErrorResponse
 
-func (response GetProductId404JSONResponse) VisitGetProductIdResponse(w http.ResponseWriter) error {
+func (response PatchProductIdImagesImageId404JSONResponse) VisitPatchProductIdImagesImageIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutProductIdRequestObject struct {
-	Id   string `json:"id"`
-	Body *PutProductIdJSONRequestBody
+type GetProductIdOptionsOptionIdStockMovementsRequestObject struct {
+	Id       string `json:"id"`
+	OptionId string `json:"optionId"`
+	Params   GetProductIdOptionsOptionIdStockMovementsParams
 }
 
-type PutProductIdResponseObject interface {
-	VisitPutProductIdResponse(w http.ResponseWriter) error
+type GetProductIdOptionsOptionIdStockMovementsResponseObject interface {
+	VisitGetProductIdOptionsOptionIdStockMovementsResponse(w http.ResponseWriter) error
 }
 
-type PutProductId200JSONResponse ProductResponse
+type GetProductIdOptionsOptionIdStockMovements200JSONResponse StockMovementPaginateResponseData
 
-func (response PutProductId200JSONResponse) VisitPutProductIdResponse(w http.ResponseWriter) error {
+func (response GetProductIdOptionsOptionIdStockMovements200JSONResponse) VisitGetProductIdOptionsOptionIdStockMovementsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PutProductId404JSONResponse ErrorResponse
+type GetProductIdOptionsOptionIdStockMovements400JSONResponse ErrorResponse
 
-func (response PutProductId404JSONResponse) VisitPutProductIdResponse(w http.ResponseWriter) error {
+func (response GetProductIdOptionsOptionIdStockMovements400JSONResponse) VisitGetProductIdOptionsOptionIdStockMovementsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProductIdOptionsOptionIdStockMovements404JSONResponse ErrorResponse
+
+func (response GetProductIdOptionsOptionIdStockMovements404JSONResponse) VisitGetProductIdOptionsOptionIdStockMovementsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductIdOptionsOptionIdStockRebuildRequestObject struct {
+	Id       string `json:"id"`
+	OptionId string `json:"optionId"`
+}
+
+type PostProductIdOptionsOptionIdStockRebuildResponseObject interface {
+	VisitPostProductIdOptionsOptionIdStockRebuildResponse(w http.ResponseWriter) error
+}
+
+type PostProductIdOptionsOptionIdStockRebuild200JSONResponse StockRebuildResponse
+
+func (response PostProductIdOptionsOptionIdStockRebuild200JSONResponse) VisitPostProductIdOptionsOptionIdStockRebuildResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductIdOptionsOptionIdStockRebuild404JSONResponse ErrorResponse
+
+func (response PostProductIdOptionsOptionIdStockRebuild404JSONResponse) VisitPostProductIdOptionsOptionIdStockRebuildResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostProductIdOptionsOptionIdStockRebuild409JSONResponse ErrorResponse
+
+func (response PostProductIdOptionsOptionIdStockRebuild409JSONResponse) VisitPostProductIdOptionsOptionIdStockRebuildResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostProductIdOptionsOptionIdStockRebuild500JSONResponse ErrorResponse
+
+func (response PostProductIdOptionsOptionIdStockRebuild500JSONResponse) VisitPostProductIdOptionsOptionIdStockRebuildResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// List all products
@@ -461,6 +1378,12 @@ type StrictServerInterface interface {
 	// Create new product
 	// (POST /product)
 	PostProduct(ctx context.Context, request PostProductRequestObject) (PostProductResponseObject, error)
+	// Export the catalogue as a CSV or XLSX file
+	// (GET /product/export)
+	GetProductExport(ctx context.Context, request GetProductExportRequestObject) (GetProductExportResponseObject, error)
+	// Import products from a CSV or XLSX file
+	// (POST /product/import)
+	PostProductImport(ctx context.Context, request PostProductImportRequestObject) (PostProductImportResponseObject, error)
 	// Delete product by ID
 	// (DELETE /product/{id})
 	DeleteProductId(ctx context.Context, request DeleteProductIdRequestObject) (DeleteProductIdResponseObject, error)
@@ -470,6 +1393,24 @@ type StrictServerInterface interface {
 	// Update product by ID (including options)
 	// (PUT /product/{id})
 	PutProductId(ctx context.Context, request PutProductIdRequestObject) (PutProductIdResponseObject, error)
+	// List the images of a product
+	// (GET /product/{id}/images)
+	GetProductIdImages(ctx context.Context, request GetProductIdImagesRequestObject) (GetProductIdImagesResponseObject, error)
+	// Upload a product image
+	// (POST /product/{id}/images)
+	PostProductIdImages(ctx context.Context, request PostProductIdImagesRequestObject) (PostProductIdImagesResponseObject, error)
+	// Delete a product image
+	// (DELETE /product/{id}/images/{imageId})
+	DeleteProductIdImagesImageId(ctx context.Context, request DeleteProductIdImagesImageIdRequestObject) (DeleteProductIdImagesImageIdResponseObject, error)
+	// Reorder a product image or make it the primary image
+	// (PATCH /product/{id}/images/{imageId})
+	PatchProductIdImagesImageId(ctx context.Context, request PatchProductIdImagesImageIdRequestObject) (PatchProductIdImagesImageIdResponseObject, error)
+	// List stock movements of a product option
+	// (GET /product/{id}/options/{optionId}/stock-movements)
+	GetProductIdOptionsOptionIdStockMovements(ctx context.Context, request GetProductIdOptionsOptionIdStockMovementsRequestObject) (GetProductIdOptionsOptionIdStockMovementsResponseObject, error)
+	// Rebuild the stock of a product option from its stock movements
+	// (POST /product/{id}/options/{optionId}/stock/rebuild)
+	PostProductIdOptionsOptionIdStockRebuild(ctx context.Context, request PostProductIdOptionsOptionIdStockRebuildRequestObject) (PostProductIdOptionsOptionIdStockRebuildResponseObject, error)
 }
 
 type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
@@ -512,9 +1453,11 @@ func (sh *strictHandler) GetProduct(ctx *gin.Context, params GetProductParams) {
 }
 
 // PostProduct operation middleware
-func (sh *strictHandler) PostProduct(ctx *gin.Context) {
+func (sh *strictHandler) PostProduct(ctx *gin.Context, params PostProductParams) {
 	var request PostProductRequestObject
 
+	request.Params = params
+
 	var body PostProductJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
@@ -544,6 +1487,65 @@ func (sh *strictHandler) PostProduct(ctx *gin.Context) {
 	}
 }
 
+// GetProductExport operation middleware
+func (sh *strictHandler) GetProductExport(ctx *gin.Context, params GetProductExportParams) {
+	var request GetProductExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProductExport(ctx, request.(GetProductExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProductExport")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetProductExportResponseObject); ok {
+		if err := validResponse.VisitGetProductExportResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostProductImport operation middleware
+func (sh *strictHandler) PostProductImport(ctx *gin.Context) {
+	var request PostProductImportRequestObject
+
+	if reader, err := ctx.Request.MultipartReader(); err == nil {
+		request.Body = reader
+	} else {
+		ctx.Error(err)
+		return
+	}
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostProductImport(ctx, request.(PostProductImportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostProductImport")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostProductImportResponseObject); ok {
+		if err := validResponse.VisitPostProductImportResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // DeleteProductId operation middleware
 func (sh *strictHandler) DeleteProductId(ctx *gin.Context, id string) {
 	var request DeleteProductIdRequestObject
@@ -633,24 +1635,253 @@ func (sh *strictHandler) PutProductId(ctx *gin.Context, id string) {
 	}
 }
 
+// GetProductIdImages operation middleware
+func (sh *strictHandler) GetProductIdImages(ctx *gin.Context, id string) {
+	var request GetProductIdImagesRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProductIdImages(ctx, request.(GetProductIdImagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProductIdImages")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetProductIdImagesResponseObject); ok {
+		if err := validResponse.VisitGetProductIdImagesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostProductIdImages operation middleware
+func (sh *strictHandler) PostProductIdImages(ctx *gin.Context, id string) {
+	var request PostProductIdImagesRequestObject
+
+	request.Id = id
+
+	if reader, err := ctx.Request.MultipartReader(); err == nil {
+		request.Body = reader
+	} else {
+		ctx.Error(err)
+		return
+	}
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostProductIdImages(ctx, request.(PostProductIdImagesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostProductIdImages")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostProductIdImagesResponseObject); ok {
+		if err := validResponse.VisitPostProductIdImagesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteProductIdImagesImageId operation middleware
+func (sh *strictHandler) DeleteProductIdImagesImageId(ctx *gin.Context, id string, imageId string) {
+	var request DeleteProductIdImagesImageIdRequestObject
+
+	request.Id = id
+	request.ImageId = imageId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteProductIdImagesImageId(ctx, request.(DeleteProductIdImagesImageIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteProductIdImagesImageId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteProductIdImagesImageIdResponseObject); ok {
+		if err := validResponse.VisitDeleteProductIdImagesImageIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PatchProductIdImagesImageId operation middleware
+func (sh *strictHandler) PatchProductIdImagesImageId(ctx *gin.Context, id string, imageId string) {
+	var request PatchProductIdImagesImageIdRequestObject
+
+	request.Id = id
+	request.ImageId = imageId
+
+	var body PatchProductIdImagesImageIdJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchProductIdImagesImageId(ctx, request.(PatchProductIdImagesImageIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchProductIdImagesImageId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PatchProductIdImagesImageIdResponseObject); ok {
+		if err := validResponse.VisitPatchProductIdImagesImageIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProductIdOptionsOptionIdStockMovements operation middleware
+func (sh *strictHandler) GetProductIdOptionsOptionIdStockMovements(ctx *gin.Context, id string, optionId string, params GetProductIdOptionsOptionIdStockMovementsParams) {
+	var request GetProductIdOptionsOptionIdStockMovementsRequestObject
+
+	request.Id = id
+	request.OptionId = optionId
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProductIdOptionsOptionIdStockMovements(ctx, request.(GetProductIdOptionsOptionIdStockMovementsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProductIdOptionsOptionIdStockMovements")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetProductIdOptionsOptionIdStockMovementsResponseObject); ok {
+		if err := validResponse.VisitGetProductIdOptionsOptionIdStockMovementsResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostProductIdOptionsOptionIdStockRebuild operation middleware
+func (sh *strictHandler) PostProductIdOptionsOptionIdStockRebuild(ctx *gin.Context, id string, optionId string) {
+	var request PostProductIdOptionsOptionIdStockRebuildRequestObject
+
+	request.Id = id
+	request.OptionId = optionId
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostProductIdOptionsOptionIdStockRebuild(ctx, request.(PostProductIdOptionsOptionIdStockRebuildRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostProductIdOptionsOptionIdStockRebuild")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostProductIdOptionsOptionIdStockRebuildResponseObject); ok {
+		if err := validResponse.VisitPostProductIdOptionsOptionIdStockRebuildResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xW0W7bNhT9FeFuDxug2c6aJ721zVAEGNAgQJ8CY2DEK5uFRCqXl+sMw/8+kBStKJZj",
-	"O81cZE82SPHw3nMOD7mG0jSt0ajZQrEGWy6xEeHvH0SGbtG2Rlv0Ay2ZFokVhmn00/4Pr1qEAiyT0gvY",
-	"5NCgtWKBI3ObHAgfnCKUUNxtP8w7sHmeFpj7r1iyB7shI13Ju/uXRuLo9kqODzeL0XEtmnEc07IyOuyl",
-	"GJvw52fCCgr4adqzNu0om3aVfg7LPECHKIjEaqd3JaHbO4+txAr7bZ8h4yOhYLzFB4f2JGbOSsGwykN8",
-	"vJCKju3vdsdeClpSZZipDDWCoYCqNoJhW5J2zT2S//TBCc2KV49wlGZc+NnD6m9Xpz0Pdv1SG5y515e3",
-	"eSMWSocWYwhdCRa7fW6deYpFd/2YQ60axWMN5dAO8+zJjB2fYsOiPoKg+F2C6jZL5eRdW8/QtD+jZUfY",
-	"kYQcHdwB95mKvrTyfxFQT/rzQ0pXJlCLtiTVpQ98vP1ylb2/uc4qQ1kbN7GZ0DLjJSrKUp05sOLaY3aV",
-	"hEXfFC+zz9tP/kayEfZiMpvMYp+oRauggHeT2eRdMAkvQ7vTtr8hFxh+PNfCo11LKOATctLYLyPRICNZ",
-	"KO7WoPwuDw5plfKoSPbr9WZymHcvg9h6JVzNUFzkI9YeB01mPgZ1djysNeRRR1CgDOrKvx7nV2/nPXAo",
-	"qFwOAJ+unPse4nkL9P8+m0VLa0Yd2BdtW6sy8D/9auPl1OMd4c7R2AvmG5ruT2U5M1Xvt+CjNq7u3iCX",
-	"r1je8EE4UtAHIbPtcdrkYLF0FC6JuzXcoyCk946XUNzNPY/WNY2gVepE1PW2FS+ZWHiPQrL3PId/fmuR",
-	"GmVtPPPbuQmhkDD3aWzsyAm4MfbREaBY4QcjV6+t3JNEGcamN/xmxz0Xr13DcwKl0OnORmZdWaK1lavr",
-	"1dtxS2Q50/gt+eVEu8T2Ye53TfE5XSu5iQlSI+Ouia7CeMfgtdyTpT6W+zwJz7z9kXc4Wy53r5qkYaxz",
-	"TMPL82mYitGGs8o4LU9TMnKaVMzuV9n11YladnL5w3/g9juXZrMfcaIlslD1W9P/E/J3id/nvhuLfXcG",
-	"6f+zu2T4hj7qLvkhznOhzredQ5HroRWzX5QuayeVXqTn+68n2jNS4++ZzebfAAAA///qroIcaRMAAA==",
+	"H4sIAAAAAAAC/+Q8W2/cOHd/hVD70ALyjJPNFqj75I2dYLaObTjJ7qJpYHCkMzPcSKRCUmNPA//34hyS",
+	"us/N16bfU8aiSJ77XfkRJSovlARpTXT0IzLJAnJOP0+1VvoKTKGkAXxQaFWAtgJoGXAZf9hVAdFRZKwW",
+	"ch7dxVEOxvA5DKzdxZGG76XQkEZHX6oXY3/Y1zhsUNO/IbF42KVWaZnY/v0JtzBXenUt0kEoEpXC4MKa",
+	"90XO5+5kYSGnH/+sYRYdRf80rmk09gQae7gmuAu3+/O41nzljpsPXiN5PgyWKqxQcm8ALmjbEASWz9un",
+	"9a5s7+gwR6SRh9bT0uFUA7qBW281cAtX8L0Es511M6VzbqOjqCzp0j1Y+axEbmO1C8VzfnsGcm4X0dGr",
+	"w8M4yoWs/o735Mc9WeEEtM8CJS1Ie+22DakPIZtec9tiUcotHFhBoPT2LEDMF7ZxnJAW5qA3aF2hjCAB",
+	"HtxUaJFzvWosTpXKgJO8G/E/BHoKJtHCqcFR9E5kwHCJCcmmKwsmimvwhbT/9iaKB66yizKfSi6y+5mA",
+	"T2H7kFyUOhtE/kakdtFYqcAZUkU8JG7zzdMgHFQxoEHWmoYtFFv83SY9Z2JIiSsSPYK57OJLJ24DqyZ5",
+	"D7ZNgjgsNp8WwDIl52AsMyIFpmbMLoBVNGPCMG5ZroxldiEMy7lcsULcApGzf89Dme5Z69jeYfA20nwu",
+	"0k32t6l0bTL8F2jFptxAysJLgRLkH2P6qewCtHtgmFmImWVWsZx/A6aVyiMydCIv8+joMN6s1u3rrS6B",
+	"zjH1lfTL7/BPPESFwziKe8bhbit9MsXTPmFmIoOWuZsK6XWnZ7jW4fAByYDglXQJpLujARJJ9oXIgFaL",
+	"Zwair73LO4JCQG+UiEJp+1aVPsTrOAJnB4Z1pZTJgsv52mUSs3QHYQ631Huah28F/jTEml0nlpW53DMG",
+	"jSOtbvpsu1I3TJb5FAVbEl+Qrk7eF8BTfG6YVjfs1YC6d9DFG2oYtqJ3BabMBvQ01atrXcph90ch8z0M",
+	"cE3PAU/ViI92PsxLFukEPb7vdq1uTJ8x544pasZSbjlywDQ5tJ0ZgYr+ggaYNb4VObfyqjYdbTDfkiwa",
+	"xjVaMJssIGXTFYIptBego6Dq1xjExdVfGNnFzIHil/wfbuV7yaUVdsW4TNGEJEC3BBxj1oinY4ZBKL0p",
+	"8jm9587i2cgtoR9jicpzzgwUXKM2skwYO2IX/k2WeGTsgls6I4OZZaq0wWYh5dk3gIL+SkqtQVq25FkJ",
+	"JmaQF3bFEsgyw5IMuMa38hHzhKyOz0tj2dSZRsNzYEoyWIJekZ6pGeOBRiN2TM9uhF344x1a1VlKZmhX",
+	"kUWmaVZH/+2CoDV61WbiHzwTaJ6IfhrwMIcgGSpD1yMVDF8KOSesdjfbceVeOpLz8Q+mNPvr7ONfDZvj",
+	"XBByKwULCTJpplXuwHFB4IhdINKOH9pYZhYAxCIu6+PwCA08HUXxdr+2p2vxyeeAYd4v916br5G0N1ac",
+	"hY7i6PZgrg78wxQSkfNsdOL+ba4eOIkgCDmGXdFc2EU5HSUqH5uFKkyBV439EUSAoG67BubtxKzaHYDf",
+	"Sr63Trg2uubHqQhoyNVyjwOv3Ptbz23EAY8B6A6xQ8BkO3G3VCLWCerPKpEPFcYtmcMWxd5ayfnHI+sl",
+	"nwtJJHWF1BNu+SMl00OqmIlcrEl8i3Y83Fkxw0tWWZ7tQCD3XjjK/RsFcOLtGf36OnPqCbYjQXYuPtO5",
+	"GyDapgntGmYnB1RLaEUhmB9T1SBsYzcLkGwuliCdv68WhGHfoLDuBZUL6yzeyxRJ+0WSWZllFC5inOHf",
+	"7GSyIZDsBcMYniqNvxBYh6FUTKSINNHiPyg2TcGFc24rRrP+JheQSuUrECCtXrEk4yI37vwR+yzDhaoB",
+	"hPchcfWwCmhxNYUM3KpmXCcLsYTUQcdZUepkwQ0wpTEH1DADDTJx7M1ddLm/02sL14ZScidJhSLj/m4X",
+	"zbcp35CqKH68MvQ2FXly1d03MW0HVoOav7l4Phj59PALsrKmkEXMQO544RWmFh/Sh550Ydpxw00thEIa",
+	"Czyl7NcJ6UC1a99e11AQ6z1bhdEQUT5alXxD05aDtGvD1b06BmHPdDUIfgqZ5X3qOsYG2VfyYIGEa3jl",
+	"3dsPPgX3+faatzRw4/gfUr2Ly9Pzyfn769+Oz47P355GcfTh+Pzz8dn18cnvnz9++nB6/imKo8uL66vT",
+	"j6dXfxx/mlycuwfvPp+9m5z5F65OP32+Oh/MFTUY0EtIr3eiQcCdhW0oXqoA2ZGw4VK1UaVOYNCTTU66",
+	"JkbprtSSUU54aSClN/MgIvEuktdnQeB7jwgVJ7a2L1qi+hQhWOuC/xeBGGF0BdNSZOl6k15oWApVmutN",
+	"wXNcvxY4OPza5kM27e1g3dD9alc8AOwQZH1i4OlCztSA1l19PmHHlxMszwSNcNU2V+ir64lWWCz2BFdJ",
+	"m6h6dVG9sgRt3LGvRoejQ+fmQPJCREfRL6PD0S/EP7sgyo+LehJiDvQPMobjaZM0Ooreg72sWglY2MvB",
+	"gjbR0ZcfkcBbvpdANR8X+AXJqMmITiv2EyAO9RmnuvSroRrr8KFBznY59XD42A7FO5XKmYAsNVg8hZm4",
+	"hdQR9YAYgjtBpliaI8s0YhoyWHKZBPPHDKCLQzNZZpaCxgWWFSHLkIM+5CSGCsPEXCrtr6CyH232Md8A",
+	"8kZpGw3i2rRXA1ax30NygW+wuXi+EzP005XA9Z6rWVsOR+xPpVMTsDKBalRBx/4lUSVmJudIgFWhXLhs",
+	"VQaO3HQ2mnr8wZMEjSC94mmzgRhErBY5tuJN9cxKrYTs5ExKMyXJ5wlrmCmnfkWAWQNEM0t7ACQkYwSL",
+	"5fM1V7mV9Vd8JdNEZpX0+fXhYWMQA3/yoshEQgo9/tsHHPV5O4S9g46OrFkbuTOfvrXxK9xuX5J784jg",
+	"tSfJBgD6jaesSoYwIIGk1OQXvvyIpsA16OMS05UvX5GOpsxd79VhgrLb6Om41OlLCCzQvt8eFKBzYYzL",
+	"Iaq1kQaeRl/dHMqASb1UZr1N7aZmFuUwiIpvaHwDDMmKjDfL9EEMmtE92Qc0XFy63LbuCpOwuQ5SLW2T",
+	"FPJCWZDJ6uA/YdWSvEa69/rXX+NhSSRi/6bS1WMLYWdK6u6u6wzueorw6rFh2CRrVRPKWWRmyiQBY7Cu",
+	"sXpZwce7//357j5mXgo6MtsRLXSExoosY1NAAS20QnpBSgC/fv18AH9a9IGjbDlDNV4xSkAIF85SMaNM",
+	"2zJ9H7vihJhJuGko4j6GxUlX9BVvDZHbGG5DjdoHcG38PloNPDfk47DZWYBmPLFi2Ui+fCVB+j4g9j5Z",
+	"xldUyUoSKGzVdvbN0Lht5VUZDjFsDpZxZoScZ7C5u+q8/Lpw89ShtVPQ6csCa4Iks2y0U91ft5m5jb6u",
+	"MWI7u9OlTEcYW9/mmYPAHKjZTCSQqqTEFG5kChQi6qDm2Yj+bYvuLr1TC7d2jHDvuXNQ1J20AMZflmdq",
+	"Ts1l5wcI47cO1YMTYZoDXesjkLu9VMBxNdSmHQAYQnLWbVjfz+c2FaPRvPF+eKDwjL3sBQHgiEAii2Fp",
+	"U1/aihIzA8AG5jcoXahVyFSzCb2aNVWj6RavNdVQB62gynht9FfHDG6FIV+uJLjzfOOSziGSNBe9I2qC",
+	"0LiuGsGQyjbnXuqxDJ4pCSOGNLpZqAyqrr+jKlURiUZWc2l44giDYQaCgdadN+rdSEjcK5c4CzGk+I2g",
+	"yJE02hRP5GVmRcG1HaMaHISS8F7uvMm53SKKRw+tW4NaaxSW6H7Da8JTQ+FGlVmKUy4VO4IddiNHjvB+",
+	"JIXYAfbZ45APXigJBaUZd798ZU+S8E2B5kgIKS4HPbHxxbA3r3553rAgiHzG9dxVJIOHlDMxLzWNOOWC",
+	"qpdoQ6xSboCXRsIeOZDZUWQ+qpzsls+nncLVitkUpP2CF3dx7fdpbujBZttHNFXBduSMWseS/xDpnbPf",
+	"2K3oZ1Qn9DxQKF0TNFALv4oZKHdfX1DaHhm86XuUkAU4OIeygDfPJ8EBGNSymSrlnvx2NK1c33TFJid7",
+	"MtezCzPhLbXF5+LZ4UvkhClY/wnHz8T/92AfxPy6CFIO1UDKZ2D9k1UjOo32F4kdOm3yDQIQ4sQXrUlM",
+	"nCeqUsDQRf+5lMIRva0X7F+ETLLSNQjoHvOve+rKWqc3rr8f3WpBJ3nVnPv57Gj9SdiQ7BBm3ZaxkCwV",
+	"hqqg1HD42WQJsa0/SDKtyfSHFpw7NTmq4Rj2++Xp+5hdnr+P2fvJO0oI/4TppQdg+4Q4xcPVmHj1pZwL",
+	"NecgfXdH9KJk/OrMuGTSFaurT5V4rUuQqBwMNWFaXzRtyxafWvCfNgetvxt7maK2/3ZyjdJVn5u9eO5Y",
+	"SlMWofLgPhrcIWN8YXPwAjmr06wdktZ9HR8VuGp1pXse2c2Nf9C/k26y18byzzAA1/7ukSyWS7twUcJt",
+	"MDNC+pGiYGL8xlHPrHTySGdYJg6mp7Av8fAh1YWPnJ46lfZUejH9cEx5WFb6SHKILLXJYiA9wcc/rRw8",
+	"Wd4z8Bn4SxVOt3itlP+0In4Ffmy3LePo7uhjeGH71u/RDLFPYMY/3I9Jejc2OMJ3EMYvd8tF/DjahT+k",
+	"Nddonk+DAhL3Ouohg23V/1TwdENuG694yoxt+xTsUFkaN1UzvFUuFxpqEm7AWJccvFhpovBa5sOjF7Id",
+	"vkDykKTS9InNOz3Mh3dXN5qLsXYjv83m64YMbshc+KHh/+PG4skVrTs7vVa3HMVtna1nkM5fsCrSl+Nn",
+	"nkQ6V/StAiaRU57RuK6GBH1r3ahX1cfCvz6nxfFMZTMuMtg7PHB7EXyn6APq7aSA5lnbtuDegcLd3f8O",
+	"AOS7KtnVTwAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,80 @@
+package purchase_order
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	svCtx "github.com/LeHNam/wao-api/context"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/shopspring/decimal"
+)
+
+// NoExchangeRateError is returned when an order mixes currencies that can not be converted into each other
+type NoExchangeRateError struct {
+	From string
+	To   string
+}
+
+func (e *NoExchangeRateError) Error() string {
+	return fmt.Sprintf("no exchange rate from %s to %s", e.From, e.To)
+}
+
+// exchangeRates converts amounts through the base currency with the rates loaded for one order
+type exchangeRates struct {
+	base  string
+	rates map[string]decimal.Decimal
+}
+
+// loadExchangeRates loads the current rates of the given currencies
+func loadExchangeRates(ctx context.Context, sc *svCtx.ServiceContext, currencies []string) (*exchangeRates, error) {
+	base := normalizeCurrency(sc.Config.Currency.Base)
+	r := &exchangeRates{
+		base:  base,
+		rates: map[string]decimal.Decimal{base: decimal.NewFromInt(1)},
+	}
+
+	rates, err := sc.ExchangeRateRepo.Find(ctx, map[string]interface{}{
+		"currency IN": currencies,
+	}, []string{}, 0, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, rate := range rates {
+		if rate.Currency != base && rate.Rate.IsPositive() {
+			r.rates[rate.Currency] = rate.Rate
+		}
+	}
+	return r, nil
+}
+
+// rate returns the number of currency units per base unit
+func (r *exchangeRates) rate(currency string) (decimal.Decimal, error) {
+	rate, ok := r.rates[currency]
+	if !ok {
+		return decimal.Zero, &NoExchangeRateError{From: r.base, To: currency}
+	}
+	return rate, nil
+}
+
+// convert converts an amount between two currencies, both need a rate against the base currency.
+// The result is rounded to the minor units of the target currency.
+func (r *exchangeRates) convert(amount decimal.Decimal, from, to string) (decimal.Decimal, error) {
+	if from == to {
+		return amount, nil
+	}
+	fromRate, ok := r.rates[from]
+	if !ok {
+		return decimal.Zero, &NoExchangeRateError{From: from, To: to}
+	}
+	toRate, ok := r.rates[to]
+	if !ok {
+		return decimal.Zero, &NoExchangeRateError{From: from, To: to}
+	}
+	// multiply first, the division is the only inexact step
+	return models.RoundMoney(amount.Mul(toRate).Div(fromRate), to), nil
+}
+
+func normalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
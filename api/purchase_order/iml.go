@@ -2,16 +2,20 @@ package purchase_order
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	svCtx "github.com/LeHNam/wao-api/context"
 	"github.com/LeHNam/wao-api/helpers/utils"
 	"github.com/LeHNam/wao-api/services/database"
+	"github.com/LeHNam/wao-api/services/outbox"
 	"github.com/LeHNam/wao-api/services/websocket"
-	"math/rand"
+	"slices"
 	"time"
 
 	"github.com/LeHNam/wao-api/models"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
 )
 
 type PurchaseOrderServer struct {
@@ -25,12 +29,7 @@ func NewPurchaseOrderServer(sc *svCtx.ServiceContext, wsService *websocket.WebSo
 		wsService: wsService,
 	}
 }
-func GenerateOrderNumber() string {
-	rand.Seed(time.Now().UnixNano())
-	randomPart := rand.Intn(10000)                       // Generate a random number between 0 and 9999
-	timestampPart := time.Now().Format("20060102150405") // Format: YYYYMMDDHHMMSS
-	return fmt.Sprintf("PO-%s-%04d", timestampPart, randomPart)
-}
+
 func (s *PurchaseOrderServer) PostPurchaseOrder(ctx context.Context, request PostPurchaseOrderRequestObject) (PostPurchaseOrderResponseObject, error) {
 	userCtx := utils.GetUserFromContext(ctx)
 
@@ -61,11 +60,40 @@ func (s *PurchaseOrderServer) PostPurchaseOrder(ctx context.Context, request Pos
 		}
 	}
 
+	// product prices are in the base currency, every other currency needs an exchange rate
+	currencies := make([]string, 0, len(request.Body.Items)+1)
+	for _, item := range request.Body.Items {
+		currencies = append(currencies, normalizeCurrency(item.Currency))
+	}
+	orderCurrency := normalizeCurrency(s.sc.Config.Currency.Base)
+	switch {
+	case request.Body.Currency != nil:
+		orderCurrency = normalizeCurrency(*request.Body.Currency)
+	case len(currencies) > 0 && !slices.ContainsFunc(currencies, func(c string) bool { return c != currencies[0] }):
+		orderCurrency = currencies[0]
+	}
+	currencies = append(currencies, orderCurrency)
+
+	rates, err := loadExchangeRates(ctx, s.sc, currencies)
+	if err != nil {
+		s.sc.Log.Error("failed to load exchange rates", zap.Error(err))
+		return PostPurchaseOrder500JSONResponse{
+			Message: utils.Stp("Failed to load exchange rates"),
+		}, nil
+	}
+	orderRate, err := rates.rate(orderCurrency)
+	if err != nil {
+		return PostPurchaseOrder400JSONResponse{
+			Message: utils.Stp(err.Error()),
+		}, nil
+	}
+
 	purchaseOrderId := uuid.New()
-	totalAmount := 0.0
+	totalAmount := decimal.Zero
+	baseTotalAmount := decimal.Zero
 	// Create purchase order items
 	items := make([]models.PurchaseOrderItem, 0, len(request.Body.Items))
-	for _, item := range request.Body.Items {
+	for i, item := range request.Body.Items {
 		product, exists := mappedProducts[item.ProductId.String()]
 		if !exists {
 			return PostPurchaseOrder400JSONResponse{
@@ -80,48 +108,53 @@ func (s *PurchaseOrderServer) PostPurchaseOrder(ctx context.Context, request Pos
 			}, nil
 		}
 
-		if option.Quantity <= 0 || option.Price <= 0 {
+		if option.ArchivedAt != nil || !option.Price.IsPositive() {
 			return PostPurchaseOrder400JSONResponse{
 				Message: utils.Stp("Product option is not available: " + item.ProductOptionId.String()),
 			}, nil
 		}
 
-		// Calculate total price based on quantity and unit price
-		totalPrice := float64(item.Quantity) * option.Price
-		if totalPrice <= 0 {
+		itemCurrency := currencies[i]
+		itemRate, err := rates.rate(itemCurrency)
+		if err != nil {
+			return PostPurchaseOrder400JSONResponse{
+				Message: utils.Stp(err.Error()),
+			}, nil
+		}
+
+		// Calculate total price based on quantity and unit price, converted to the item currency
+		unitPrice := models.RoundMoney(option.Price.Mul(itemRate), itemCurrency)
+		totalPrice := unitPrice.Mul(decimal.NewFromInt(int64(item.Quantity)))
+		if !totalPrice.IsPositive() {
 			return PostPurchaseOrder400JSONResponse{
 				Message: utils.Stp("Total price must be greater than zero"),
 			}, nil
 		}
-		totalAmount += totalPrice
+		orderPrice, err := rates.convert(totalPrice, itemCurrency, orderCurrency)
+		if err != nil {
+			return PostPurchaseOrder400JSONResponse{
+				Message: utils.Stp(err.Error()),
+			}, nil
+		}
+		totalAmount = totalAmount.Add(orderPrice)
+		baseTotalAmount = baseTotalAmount.Add(option.Price.Mul(decimal.NewFromInt(int64(item.Quantity))))
 		items = append(items, models.PurchaseOrderItem{
 			ID:                uuid.New(),
 			ProductID:         item.ProductId,
 			ProductName:       product.Name,
 			ProductOptionID:   item.ProductOptionId,
 			ProductOptionName: option.Name,
-			UnitPrice:         option.Price,
+			UnitPrice:         unitPrice,
 			TotalPrice:        totalPrice,
 			Quantity:          item.Quantity,
-			Currency:          item.Currency,
+			Currency:          itemCurrency,
+			ExchangeRate:      itemRate,
 			CreatedAt:         time.Now(),
 			UpdatedAt:         time.Now(),
 			PurchaseOrderID:   purchaseOrderId,
 		})
 	}
 
-	// Create purchase order
-	purchaseOrder := &models.PurchaseOrder{
-		ID:          purchaseOrderId,
-		OrderNumber: GenerateOrderNumber(),
-		Status:      "DRAFT",
-		TotalAmount: totalAmount,
-		OrderDate:   time.Now(),
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		CreatedBy:   userCtx.ID,
-	}
-
 	tx := s.sc.DB.Begin().WithContext(ctx)
 	if tx.Error != nil {
 		s.sc.Log.Error(tx.Error.Error())
@@ -131,8 +164,34 @@ func (s *PurchaseOrderServer) PostPurchaseOrder(ctx context.Context, request Pos
 	}
 	defer tx.Rollback()
 
+	now := time.Now()
+	orderNumber, err := s.sc.OrderNumbers.Next(ctx, tx, now)
+	if err != nil {
+		s.sc.Log.Error("failed to generate order number", zap.Error(err))
+		return PostPurchaseOrder500JSONResponse{
+			Message: utils.Stp("Failed to generate order number"),
+		}, nil
+	}
+
+	// Create purchase order
+	purchaseOrder := &models.PurchaseOrder{
+		ID:              purchaseOrderId,
+		OrderNumber:     orderNumber,
+		Status:          string(DRAFT),
+		TotalAmount:     totalAmount,
+		Currency:        orderCurrency,
+		BaseCurrency:    rates.base,
+		BaseTotalAmount: models.RoundMoney(baseTotalAmount, rates.base),
+		ExchangeRate:    orderRate,
+		OrderDate:       now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		CreatedBy:       userCtx.ID,
+	}
+
 	err = s.sc.PurchaseOrderRepo.WithTx(tx).Create(ctx, purchaseOrder)
 	if err != nil {
+		s.sc.Log.Error("failed to create purchase order", zap.Error(err))
 		return PostPurchaseOrder400JSONResponse{
 			Message: utils.Stp("Failed to create purchase order"),
 		}, nil
@@ -145,7 +204,39 @@ func (s *PurchaseOrderServer) PostPurchaseOrder(ctx context.Context, request Pos
 		}, nil
 	}
 
-	tx.Commit()
+	err = s.reserveStock(ctx, tx, items)
+	if err != nil {
+		var stockErr *InsufficientStockError
+		if errors.As(err, &stockErr) {
+			return PostPurchaseOrder409JSONResponse{
+				Message: utils.Stp(stockErr.Error()),
+			}, nil
+		}
+		s.sc.Log.Error("failed to reserve stock", zap.Error(err))
+		return PostPurchaseOrder500JSONResponse{
+			Message: utils.Stp("Failed to reserve stock"),
+		}, nil
+	}
+
+	event, err := outbox.NewEvent(string(websocket.EventOrderCreated), purchaseOrder, purchaseOrder.CreatedBy, websocket.PurchaseOrderTopic(purchaseOrder.ID))
+	if err == nil {
+		err = s.sc.OutboxEventRepo.WithTx(tx).Create(ctx, event)
+	}
+	if err != nil {
+		s.sc.Log.Error("failed to write purchase order event", zap.Error(err))
+		return PostPurchaseOrder500JSONResponse{
+			Message: utils.Stp("Failed to create purchase order"),
+		}, nil
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit purchase order", zap.Error(err))
+		return PostPurchaseOrder500JSONResponse{
+			Message: utils.Stp("Failed to create purchase order"),
+		}, nil
+	}
+	s.sc.Outbox.Notify()
+
 	return PostPurchaseOrder200JSONResponse{
 		Id: &purchaseOrder.ID,
 	}, nil
@@ -153,38 +244,120 @@ func (s *PurchaseOrderServer) PostPurchaseOrder(ctx context.Context, request Pos
 
 func (s *PurchaseOrderServer) GetPurchaseOrder(ctx context.Context, request GetPurchaseOrderRequestObject) (GetPurchaseOrderResponseObject, error) {
 	userCtx := utils.GetUserFromContext(ctx)
+	params := request.Params
+
+	page := params.Page
+	limit := params.Limit
+	if page < 1 || limit < 1 {
+		return GetPurchaseOrder400JSONResponse{
+			Message: utils.Stp("page and limit must be greater than zero"),
+		}, nil
+	}
+	offset := (page - 1) * limit
 
 	cond := map[string]any{}
 
-	if userCtx.Role == "buyer" {
-		cond["created_by"] = userCtx.ID
+	if params.Status != nil && len(*params.Status) > 0 {
+		statuses := make([]string, 0, len(*params.Status))
+		for _, status := range *params.Status {
+			if !status.Valid() {
+				return GetPurchaseOrder400JSONResponse{
+					Message: utils.Stp(fmt.Sprintf("invalid status %q", status)),
+				}, nil
+			}
+			statuses = append(statuses, string(status))
+		}
+		cond["status"+database.CONDITION_IN] = statuses
+	}
+
+	switch {
+	case params.OrderDateFrom != nil && params.OrderDateTo != nil:
+		if params.OrderDateFrom.After(*params.OrderDateTo) {
+			return GetPurchaseOrder400JSONResponse{
+				Message: utils.Stp("order_date_from must be before order_date_to"),
+			}, nil
+		}
+		cond["order_date"+database.CONDITION_BETWEEN_AND] = []interface{}{*params.OrderDateFrom, *params.OrderDateTo}
+	case params.OrderDateFrom != nil:
+		cond["order_date"+database.CONDITION_GREATER_THAN_OR_EQUAL] = *params.OrderDateFrom
+	case params.OrderDateTo != nil:
+		cond["order_date"+database.CONDITION_LESS_THAN_OR_EQUAL] = *params.OrderDateTo
+	}
+
+	// the bounds are bound as float64, decimal.NewFromFloat keeps the shortest representation, e.g. 10.1
+	switch {
+	case params.MinTotal != nil && params.MaxTotal != nil:
+		if *params.MinTotal > *params.MaxTotal {
+			return GetPurchaseOrder400JSONResponse{
+				Message: utils.Stp("min_total must not be greater than max_total"),
+			}, nil
+		}
+		cond["total_amount"+database.CONDITION_BETWEEN_AND] = []interface{}{decimal.NewFromFloat(*params.MinTotal), decimal.NewFromFloat(*params.MaxTotal)}
+	case params.MinTotal != nil:
+		cond["total_amount"+database.CONDITION_GREATER_THAN_OR_EQUAL] = decimal.NewFromFloat(*params.MinTotal)
+	case params.MaxTotal != nil:
+		cond["total_amount"+database.CONDITION_LESS_THAN_OR_EQUAL] = decimal.NewFromFloat(*params.MaxTotal)
+	}
+
+	if params.OrderNumber != nil && *params.OrderNumber != "" {
+		cond["order_number"+database.CONDITION_LIKE] = *params.OrderNumber
 	}
-	orders, err := s.sc.PurchaseOrderRepo.Find(ctx, cond, []string{}, 0, 0, nil)
+
+	if params.CreatedBy != nil {
+		cond["created_by"] = *params.CreatedBy
+	}
+	cond = ScopeConditions(userCtx, cond)
+
+	orders, err := s.sc.PurchaseOrderRepo.Find(ctx, cond, []string{}, limit, offset, params.Sort)
 	if err != nil {
+		s.sc.Log.Error("failed to fetch purchase orders", zap.Error(err))
+		return GetPurchaseOrder500JSONResponse{
+			Message: utils.Stp("Failed to fetch purchase orders"),
+		}, nil
+	}
+	total, err := s.sc.PurchaseOrderRepo.Count(ctx, cond)
+	if err != nil {
+		s.sc.Log.Error("failed to count purchase orders", zap.Error(err))
 		return GetPurchaseOrder500JSONResponse{
 			Message: utils.Stp("Failed to fetch purchase orders"),
 		}, nil
 	}
 
-	response := make([]PurchaseOrder, 0, len(orders))
+	items := make([]PurchaseOrder, 0, len(orders))
 	for _, order := range orders {
-		response = append(response, PurchaseOrder{
-			Id:          order.ID,
-			Status:      order.Status,
-			OrderDate:   order.OrderDate,
-			TotalAmount: float32(order.TotalAmount),
-			Currency:    order.Currency,
-			OrderNumber: order.OrderNumber,
+		items = append(items, PurchaseOrder{
+			Id:              order.ID,
+			Status:          PurchaseOrderStatus(order.Status),
+			OrderDate:       order.OrderDate,
+			TotalAmount:     order.TotalAmount,
+			Currency:        order.Currency,
+			BaseCurrency:    &order.BaseCurrency,
+			BaseTotalAmount: &order.BaseTotalAmount,
+			ExchangeRate:    &order.ExchangeRate,
+			OrderNumber:     order.OrderNumber,
+			Timezone:        &order.Timezone,
+			Notes:           order.Notes,
+			CreatedAt:       &order.CreatedAt,
+			UpdatedAt:       &order.UpdatedAt,
+			CreatedBy:       &order.CreatedBy,
+			UpdatedBy:       &order.UpdatedBy,
 		})
 	}
 
-	return GetPurchaseOrder200JSONResponse(response), nil
+	return GetPurchaseOrder200JSONResponse{
+		Items: items,
+		Limit: limit,
+		Page:  page,
+		Pages: (int(total) + limit - 1) / limit,
+		Total: int(total),
+	}, nil
 }
 
 func (s *PurchaseOrderServer) GetPurchaseOrderId(ctx context.Context, request GetPurchaseOrderIdRequestObject) (GetPurchaseOrderIdResponseObject, error) {
+	userCtx := utils.GetUserFromContext(ctx)
 
 	order, err := s.sc.PurchaseOrderRepo.First(ctx, request.Id)
-	if err != nil {
+	if err != nil || !CanAccessOrder(userCtx, order) {
 		return GetPurchaseOrderId404JSONResponse{
 			Message: utils.Stp("Purchase order not found"),
 		}, nil
@@ -204,49 +377,122 @@ func (s *PurchaseOrderServer) GetPurchaseOrderId(ctx context.Context, request Ge
 			ProductName:       item.ProductName,
 			ProductOptionId:   item.ProductOptionID,
 			ProductOptionName: item.ProductOptionName,
-			UnitPrice:         float32(item.UnitPrice),
-			TotalPrice:        float32(item.TotalPrice),
+			UnitPrice:         item.UnitPrice,
+			TotalPrice:        item.TotalPrice,
 			Currency:          item.Currency,
+			ExchangeRate:      &item.ExchangeRate,
 			Quantity:          item.Quantity,
 		})
 	}
 	return GetPurchaseOrderId200JSONResponse{
-		Id:          order.ID,
-		Status:      order.Status,
-		OrderDate:   order.OrderDate,
-		TotalAmount: float32(order.TotalAmount),
-		Currency:    order.Currency,
-		OrderNumber: order.OrderNumber,
-		Items:       &responseItems,
+		Id:              order.ID,
+		Status:          PurchaseOrderStatus(order.Status),
+		OrderDate:       order.OrderDate,
+		TotalAmount:     order.TotalAmount,
+		Currency:        order.Currency,
+		BaseCurrency:    &order.BaseCurrency,
+		BaseTotalAmount: &order.BaseTotalAmount,
+		ExchangeRate:    &order.ExchangeRate,
+		OrderNumber:     order.OrderNumber,
+		Items:           &responseItems,
 	}, nil
 }
 
 func (s *PurchaseOrderServer) PatchPurchaseOrderIdStatus(ctx context.Context, request PatchPurchaseOrderIdStatusRequestObject) (PatchPurchaseOrderIdStatusResponseObject, error) {
+	userCtx := utils.GetUserFromContext(ctx)
 
-	updateData := map[string]any{
-		"status":     request.Body.Status,
-		"updated_at": time.Now(),
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PatchPurchaseOrderIdStatus500JSONResponse{
+			Message: utils.Stp("Create DB transaction failed"),
+		}, nil
 	}
+	defer tx.Rollback()
 
-	order, err := s.sc.PurchaseOrderRepo.First(ctx, request.Id)
+	order, err := s.sc.PurchaseOrderRepo.WithTx(tx).FirstForUpdate(ctx, request.Id)
 	if err != nil {
-		return PatchPurchaseOrderIdStatus400JSONResponse{
+		if database.IsNotFoundError(err) {
+			return PatchPurchaseOrderIdStatus404JSONResponse{
+				Message: utils.Stp("Purchase order not found"),
+			}, nil
+		}
+		s.sc.Log.Error("failed to load purchase order", zap.Error(err))
+		return PatchPurchaseOrderIdStatus500JSONResponse{
+			Message: utils.Stp("Failed to load purchase order"),
+		}, nil
+	}
+	if !CanAccessOrder(userCtx, order) {
+		return PatchPurchaseOrderIdStatus404JSONResponse{
 			Message: utils.Stp("Purchase order not found"),
 		}, nil
 	}
 
-	err = s.sc.PurchaseOrderRepo.Update(ctx, request.Id, updateData)
-	if err != nil {
+	err = CheckStatusTransition(PurchaseOrderStatus(order.Status), request.Body.Status, userCtx.Role)
+	switch {
+	case errors.Is(err, ErrInvalidStatus):
 		return PatchPurchaseOrderIdStatus400JSONResponse{
+			Message: utils.Stp(err.Error()),
+		}, nil
+	case errors.Is(err, ErrStatusTransitionForbidden):
+		return PatchPurchaseOrderIdStatus403JSONResponse{
+			Message: utils.Stp(err.Error()),
+		}, nil
+	case errors.Is(err, ErrStatusTransitionNotAllowed):
+		return PatchPurchaseOrderIdStatus409JSONResponse{
+			Message: utils.Stp(fmt.Sprintf("Cannot change status from %s to %s", order.Status, request.Body.Status)),
+		}, nil
+	}
+
+	err = s.applyStockTransition(ctx, tx, order.ID, PurchaseOrderStatus(order.Status), request.Body.Status)
+	if err != nil {
+		var stockErr *InsufficientStockError
+		if errors.As(err, &stockErr) {
+			return PatchPurchaseOrderIdStatus409JSONResponse{
+				Message: utils.Stp(stockErr.Error()),
+			}, nil
+		}
+		s.sc.Log.Error("failed to update stock for status change", zap.Error(err))
+		return PatchPurchaseOrderIdStatus500JSONResponse{
+			Message: utils.Stp("Failed to update stock"),
+		}, nil
+	}
+
+	now := time.Now()
+	updateData := map[string]any{
+		"status":     string(request.Body.Status),
+		"updated_at": now,
+		"updated_by": userCtx.ID,
+	}
+	err = s.sc.PurchaseOrderRepo.WithTx(tx).Update(ctx, request.Id, updateData)
+	if err != nil {
+		s.sc.Log.Error("failed to update purchase order status", zap.Error(err))
+		return PatchPurchaseOrderIdStatus500JSONResponse{
+			Message: utils.Stp("Failed to update status"),
+		}, nil
+	}
+
+	order.Status = string(request.Body.Status)
+	order.UpdatedAt = now
+	order.UpdatedBy = userCtx.ID
+	event, err := outbox.NewEvent(string(websocket.EventOrderUpdated), order, order.CreatedBy, websocket.PurchaseOrderTopic(order.ID))
+	if err == nil {
+		err = s.sc.OutboxEventRepo.WithTx(tx).Create(ctx, event)
+	}
+	if err != nil {
+		s.sc.Log.Error("failed to write purchase order event", zap.Error(err))
+		return PatchPurchaseOrderIdStatus500JSONResponse{
 			Message: utils.Stp("Failed to update status"),
 		}, nil
 	}
-	order.Status = request.Body.Status
-	message := map[string]any{
-		"event": "order_updated",
-		"data":  order,
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit purchase order status", zap.Error(err))
+		return PatchPurchaseOrderIdStatus500JSONResponse{
+			Message: utils.Stp("Failed to update status"),
+		}, nil
 	}
-	_ = s.wsService.Broadcast(message)
+	s.sc.Outbox.Notify()
 
 	success := true
 	return PatchPurchaseOrderIdStatus200JSONResponse{
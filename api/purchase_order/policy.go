@@ -0,0 +1,37 @@
+package purchase_order
+
+import (
+	"github.com/LeHNam/wao-api/models"
+	"github.com/google/uuid"
+)
+
+// Row-level access to purchase orders.
+// Staff and admins can see and modify every order, buyers only the orders they created.
+// Every purchase order operation goes through ScopeConditions or CanAccessOrder, an order
+// a user can not access is reported as not found so its existence is not leaked.
+
+// ScopeConditions adds the conditions restricting a query to the orders the user can access
+func ScopeConditions(user *models.User, cond map[string]any) map[string]any {
+	if cond == nil {
+		cond = map[string]any{}
+	}
+	if user == nil || !user.Role.IsStaff() {
+		owner := uuid.Nil
+		if user != nil {
+			owner = user.ID
+		}
+		cond["created_by"] = owner
+	}
+	return cond
+}
+
+// CanAccessOrder reports whether the user can see and modify the order
+func CanAccessOrder(user *models.User, order *models.PurchaseOrder) bool {
+	if user == nil || order == nil {
+		return false
+	}
+	if user.Role.IsStaff() {
+		return true
+	}
+	return order.CreatedBy == user.ID
+}
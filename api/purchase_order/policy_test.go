@@ -0,0 +1,240 @@
+package purchase_order
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+	"github.com/LeHNam/wao-api/constant"
+	svCtx "github.com/LeHNam/wao-api/context"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/LeHNam/wao-api/services/outbox"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// fakeConnPool lets handlers open and commit transactions, every query goes through the fake repositories
+type fakeConnPool struct{}
+
+func (*fakeConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, sql.ErrConnDone
+}
+
+func (*fakeConnPool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return nil, sql.ErrConnDone
+}
+
+func (*fakeConnPool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return nil, sql.ErrConnDone
+}
+
+func (*fakeConnPool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return nil
+}
+
+func (p *fakeConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return p, nil
+}
+
+func (*fakeConnPool) Commit() error   { return nil }
+func (*fakeConnPool) Rollback() error { return nil }
+
+// fakeRepo accepts every write and finds nothing, methods it does not override panic
+type fakeRepo[T any] struct {
+	database.Repository[T]
+}
+
+func (r *fakeRepo[T]) WithTx(tx *gorm.DB) database.Repository[T]          { return r }
+func (r *fakeRepo[T]) Create(ctx context.Context, entity *T) error        { return nil }
+func (r *fakeRepo[T]) CreateMany(ctx context.Context, entities []T) error { return nil }
+func (r *fakeRepo[T]) Update(ctx context.Context, id uuid.UUID, updates map[string]any) error {
+	return nil
+}
+func (r *fakeRepo[T]) Find(ctx context.Context, conditions map[string]any, selectFields []string, limit, offset int, sort *string) ([]T, error) {
+	return nil, nil
+}
+func (r *fakeRepo[T]) FindForUpdate(ctx context.Context, conditions map[string]any) ([]T, error) {
+	return nil, nil
+}
+
+// fakeOrderRepo keeps purchase orders in memory and honours the created_by condition
+type fakeOrderRepo struct {
+	fakeRepo[models.PurchaseOrder]
+	orders map[uuid.UUID]models.PurchaseOrder
+}
+
+func (r *fakeOrderRepo) WithTx(tx *gorm.DB) database.Repository[models.PurchaseOrder] { return r }
+
+func (r *fakeOrderRepo) First(ctx context.Context, id uuid.UUID) (*models.PurchaseOrder, error) {
+	order, ok := r.orders[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &order, nil
+}
+
+func (r *fakeOrderRepo) FirstForUpdate(ctx context.Context, id uuid.UUID) (*models.PurchaseOrder, error) {
+	return r.First(ctx, id)
+}
+
+func (r *fakeOrderRepo) Find(ctx context.Context, conditions map[string]any, selectFields []string, limit, offset int, sort *string) ([]models.PurchaseOrder, error) {
+	var orders []models.PurchaseOrder
+	for _, order := range r.orders {
+		if owner, ok := conditions["created_by"]; ok && owner != order.CreatedBy {
+			continue
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+func (r *fakeOrderRepo) Count(ctx context.Context, conditions map[string]any) (int64, error) {
+	orders, err := r.Find(ctx, conditions, nil, 0, 0, nil)
+	return int64(len(orders)), err
+}
+
+func newPolicyTestRouter(t *testing.T, user *models.User, orders ...models.PurchaseOrder) *gin.Engine {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: &fakeConnPool{}}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderRepo := &fakeOrderRepo{orders: map[uuid.UUID]models.PurchaseOrder{}}
+	for _, order := range orders {
+		orderRepo.orders[order.ID] = order
+	}
+	cfg := &config.Config{}
+	sc := &svCtx.ServiceContext{
+		Config:                cfg,
+		DB:                    db,
+		Log:                   zap.NewNop(),
+		Outbox:                outbox.NewDispatcher(cfg, db, zap.NewNop()),
+		PurchaseOrderRepo:     orderRepo,
+		PurchaseOrderItemRepo: &fakeRepo[models.PurchaseOrderItem]{},
+		ProductOptionRepo:     &fakeRepo[models.ProductOption]{},
+		StockMovementRepo:     &fakeRepo[models.StockMovement]{},
+		OutboxEventRepo:       &fakeRepo[models.OutboxEvent]{},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user", user)
+	})
+	RegisterHandlers(router, NewStrictHandler(NewPurchaseOrderServer(sc, nil), nil))
+	return router
+}
+
+func TestPurchaseOrderOwnership(t *testing.T) {
+	ownerID := uuid.New()
+	users := map[string]*models.User{
+		"buyer": {ID: ownerID, Role: constant.RoleBuyer},
+		"staff": {ID: ownerID, Role: constant.RoleStaff},
+		"admin": {ID: ownerID, Role: constant.RoleAdmin},
+	}
+	own := models.PurchaseOrder{ID: uuid.New(), Status: string(SUBMITTED), CreatedBy: ownerID, OrderDate: time.Now()}
+	foreign := models.PurchaseOrder{ID: uuid.New(), Status: string(SUBMITTED), CreatedBy: uuid.New(), OrderDate: time.Now()}
+
+	tests := []struct {
+		name   string
+		user   string
+		order  models.PurchaseOrder
+		method string
+		path   string
+		body   any
+		want   int
+	}{
+		{"buyer gets own order", "buyer", own, http.MethodGet, "", nil, http.StatusOK},
+		{"buyer gets foreign order", "buyer", foreign, http.MethodGet, "", nil, http.StatusNotFound},
+		{"staff gets own order", "staff", own, http.MethodGet, "", nil, http.StatusOK},
+		{"staff gets foreign order", "staff", foreign, http.MethodGet, "", nil, http.StatusOK},
+		{"admin gets own order", "admin", own, http.MethodGet, "", nil, http.StatusOK},
+		{"admin gets foreign order", "admin", foreign, http.MethodGet, "", nil, http.StatusOK},
+
+		{"buyer cancels own order", "buyer", own, http.MethodPatch, "/status", PatchPurchaseOrderIdStatusJSONRequestBody{Status: CANCELLED}, http.StatusOK},
+		{"buyer cancels foreign order", "buyer", foreign, http.MethodPatch, "/status", PatchPurchaseOrderIdStatusJSONRequestBody{Status: CANCELLED}, http.StatusNotFound},
+		{"staff cancels own order", "staff", own, http.MethodPatch, "/status", PatchPurchaseOrderIdStatusJSONRequestBody{Status: CANCELLED}, http.StatusOK},
+		{"staff cancels foreign order", "staff", foreign, http.MethodPatch, "/status", PatchPurchaseOrderIdStatusJSONRequestBody{Status: CANCELLED}, http.StatusOK},
+		{"admin cancels own order", "admin", own, http.MethodPatch, "/status", PatchPurchaseOrderIdStatusJSONRequestBody{Status: CANCELLED}, http.StatusOK},
+		{"admin cancels foreign order", "admin", foreign, http.MethodPatch, "/status", PatchPurchaseOrderIdStatusJSONRequestBody{Status: CANCELLED}, http.StatusOK},
+
+		// the role check only runs once the order is visible, a foreign order stays hidden
+		{"buyer approves own order", "buyer", own, http.MethodPatch, "/status", PatchPurchaseOrderIdStatusJSONRequestBody{Status: APPROVED}, http.StatusForbidden},
+		{"buyer approves foreign order", "buyer", foreign, http.MethodPatch, "/status", PatchPurchaseOrderIdStatusJSONRequestBody{Status: APPROVED}, http.StatusNotFound},
+		{"staff approves foreign order", "staff", foreign, http.MethodPatch, "/status", PatchPurchaseOrderIdStatusJSONRequestBody{Status: APPROVED}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newPolicyTestRouter(t, users[tt.user], own, foreign)
+
+			var body bytes.Buffer
+			if tt.body != nil {
+				if err := json.NewEncoder(&body).Encode(tt.body); err != nil {
+					t.Fatal(err)
+				}
+			}
+			req := httptest.NewRequest(tt.method, "/purchase-order/"+tt.order.ID.String()+tt.path, &body)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, req.URL.Path, rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestPurchaseOrderListScope(t *testing.T) {
+	ownerID := uuid.New()
+	own := models.PurchaseOrder{ID: uuid.New(), CreatedBy: ownerID}
+	foreign := models.PurchaseOrder{ID: uuid.New(), CreatedBy: uuid.New()}
+
+	tests := []struct {
+		name string
+		role constant.Role
+		// created_by filter sent by the client, buyers can not widen their scope with it
+		createdBy uuid.UUID
+		want      int
+	}{
+		{"buyer", constant.RoleBuyer, uuid.Nil, 1},
+		{"buyer filtering on another user", constant.RoleBuyer, foreign.CreatedBy, 1},
+		{"staff", constant.RoleStaff, uuid.Nil, 2},
+		{"admin", constant.RoleAdmin, uuid.Nil, 2},
+		{"admin filtering on a user", constant.RoleAdmin, foreign.CreatedBy, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newPolicyTestRouter(t, &models.User{ID: ownerID, Role: tt.role}, own, foreign)
+
+			target := "/purchase-order?page=1&limit=10"
+			if tt.createdBy != uuid.Nil {
+				target += "&created_by=" + tt.createdBy.String()
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("GET %s = %d: %s", target, rec.Code, rec.Body.String())
+			}
+
+			var resp GetPurchaseOrder200JSONResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Total != tt.want {
+				t.Errorf("total = %d, want %d", resp.Total, tt.want)
+			}
+		})
+	}
+}
@@ -21,36 +21,62 @@ import (
 	"github.com/oapi-codegen/runtime"
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
 	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/shopspring/decimal"
 )
 
 const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for PurchaseOrderStatus.
+const (
+	APPROVED  PurchaseOrderStatus = "APPROVED"
+	CANCELLED PurchaseOrderStatus = "CANCELLED"
+	DRAFT     PurchaseOrderStatus = "DRAFT"
+	FULFILLED PurchaseOrderStatus = "FULFILLED"
+	REJECTED  PurchaseOrderStatus = "REJECTED"
+	SUBMITTED PurchaseOrderStatus = "SUBMITTED"
+)
+
 // PurchaseOrder defines model for PurchaseOrder.
 type PurchaseOrder struct {
-	CreatedAt   *time.Time           `json:"created_at,omitempty"`
-	CreatedBy   *openapi_types.UUID  `json:"created_by,omitempty"`
-	Currency    string               `json:"currency"`
-	DeletedAt   *time.Time           `json:"deleted_at"`
-	Id          openapi_types.UUID   `json:"id"`
-	Items       *[]PurchaseOrderItem `json:"items,omitempty"`
-	Notes       *string              `json:"notes"`
-	OrderDate   time.Time            `json:"order_date"`
-	OrderNumber string               `json:"order_number"`
-	Status      string               `json:"status"`
-	Timezone    *string              `json:"timezone,omitempty"`
-	TotalAmount float32              `json:"total_amount"`
-	UpdatedAt   *time.Time           `json:"updated_at,omitempty"`
-	UpdatedBy   *openapi_types.UUID  `json:"updated_by,omitempty"`
+	// BaseCurrency Base currency when the order was created
+	BaseCurrency *string `json:"base_currency,omitempty"`
+
+	// BaseTotalAmount Total amount in the base currency
+	BaseTotalAmount *decimal.Decimal    `json:"base_total_amount,omitempty"`
+	CreatedAt       *time.Time          `json:"created_at,omitempty"`
+	CreatedBy       *openapi_types.UUID `json:"created_by,omitempty"`
+	Currency        string              `json:"currency"`
+	DeletedAt       *time.Time          `json:"deleted_at"`
+
+	// ExchangeRate Units of the order currency per unit of the base currency when the order was created
+	ExchangeRate *decimal.Decimal     `json:"exchange_rate,omitempty"`
+	Id           openapi_types.UUID   `json:"id"`
+	Items        *[]PurchaseOrderItem `json:"items,omitempty"`
+	Notes        *string              `json:"notes"`
+	OrderDate    time.Time            `json:"order_date"`
+	OrderNumber  string               `json:"order_number"`
+
+	// Status Lifecycle of a purchase order:
+	// DRAFT -> SUBMITTED -> APPROVED -> FULFILLED.
+	// DRAFT, SUBMITTED and APPROVED orders can be CANCELLED, SUBMITTED orders can be REJECTED.
+	Status      PurchaseOrderStatus `json:"status"`
+	Timezone    *string             `json:"timezone,omitempty"`
+	TotalAmount decimal.Decimal     `json:"total_amount"`
+	UpdatedAt   *time.Time          `json:"updated_at,omitempty"`
+	UpdatedBy   *openapi_types.UUID `json:"updated_by,omitempty"`
 }
 
 // PurchaseOrderItem defines model for PurchaseOrderItem.
 type PurchaseOrderItem struct {
-	CreatedAt         *time.Time          `json:"created_at,omitempty"`
-	CreatedBy         *openapi_types.UUID `json:"created_by,omitempty"`
-	Currency          string              `json:"currency"`
-	DeletedAt         *time.Time          `json:"deleted_at"`
+	CreatedAt *time.Time          `json:"created_at,omitempty"`
+	CreatedBy *openapi_types.UUID `json:"created_by,omitempty"`
+	Currency  string              `json:"currency"`
+	DeletedAt *time.Time          `json:"deleted_at"`
+
+	// ExchangeRate Units of the item currency per unit of the base currency the prices were converted with
+	ExchangeRate      *decimal.Decimal    `json:"exchange_rate,omitempty"`
 	Id                openapi_types.UUID  `json:"id"`
 	ProductId         openapi_types.UUID  `json:"product_id"`
 	ProductName       string              `json:"product_name"`
@@ -58,15 +84,52 @@ type PurchaseOrderItem struct {
 	ProductOptionName string              `json:"product_option_name"`
 	PurchaseOrderId   openapi_types.UUID  `json:"purchase_order_id"`
 	Quantity          int                 `json:"quantity"`
-	TotalPrice        float32             `json:"total_price"`
-	UnitPrice         float32             `json:"unit_price"`
+	TotalPrice        decimal.Decimal     `json:"total_price"`
+	UnitPrice         decimal.Decimal     `json:"unit_price"`
 	UpdatedAt         *time.Time          `json:"updated_at,omitempty"`
 	UpdatedBy         *openapi_types.UUID `json:"updated_by,omitempty"`
 }
 
+// PurchaseOrderPaginateResponseData defines model for PurchaseOrderPaginateResponseData.
+type PurchaseOrderPaginateResponseData struct {
+	Items []PurchaseOrder `json:"items"`
+	Limit int             `json:"limit"`
+	Page  int             `json:"page"`
+	Pages int             `json:"pages"`
+	Total int             `json:"total"`
+}
+
+// PurchaseOrderStatus Lifecycle of a purchase order:
+// DRAFT -> SUBMITTED -> APPROVED -> FULFILLED.
+// DRAFT, SUBMITTED and APPROVED orders can be CANCELLED, SUBMITTED orders can be REJECTED.
+type PurchaseOrderStatus string
+
+// GetPurchaseOrderParams defines parameters for GetPurchaseOrder.
+type GetPurchaseOrderParams struct {
+	Page  int `form:"page" json:"page"`
+	Limit int `form:"limit" json:"limit"`
+
+	// Sort Comma separated fields, prefix a field with - for descending order
+	Sort          *string                `form:"sort,omitempty" json:"sort,omitempty"`
+	Status        *[]PurchaseOrderStatus `form:"status,omitempty" json:"status,omitempty"`
+	OrderDateFrom *time.Time             `form:"order_date_from,omitempty" json:"order_date_from,omitempty"`
+	OrderDateTo   *time.Time             `form:"order_date_to,omitempty" json:"order_date_to,omitempty"`
+
+	// CreatedBy Ignored for buyers, they only see their own orders
+	CreatedBy *openapi_types.UUID `form:"created_by,omitempty" json:"created_by,omitempty"`
+
+	// OrderNumber Search in order number
+	OrderNumber *string  `form:"order_number,omitempty" json:"order_number,omitempty"`
+	MinTotal    *float64 `form:"min_total,omitempty" json:"min_total,omitempty"`
+	MaxTotal    *float64 `form:"max_total,omitempty" json:"max_total,omitempty"`
+}
+
 // PostPurchaseOrderJSONBody defines parameters for PostPurchaseOrder.
 type PostPurchaseOrderJSONBody struct {
-	Items []struct {
+	// Currency Currency of the order totals. Defaults to the item currency when every item uses the same one,
+	// to the base currency otherwise. Every currency used needs an exchange rate against the base currency.
+	Currency *string `json:"currency,omitempty"`
+	Items    []struct {
 		Currency        string             `json:"currency"`
 		ProductId       openapi_types.UUID `json:"product_id"`
 		ProductOptionId openapi_types.UUID `json:"product_option_id"`
@@ -74,9 +137,18 @@ type PostPurchaseOrderJSONBody struct {
 	} `json:"items"`
 }
 
+// PostPurchaseOrderParams defines parameters for PostPurchaseOrder.
+type PostPurchaseOrderParams struct {
+	// IdempotencyKey Retries with the same key replay the first response instead of creating another purchase order
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
 // PatchPurchaseOrderIdStatusJSONBody defines parameters for PatchPurchaseOrderIdStatus.
 type PatchPurchaseOrderIdStatusJSONBody struct {
-	Status string `json:"status"`
+	// Status Lifecycle of a purchase order:
+	// DRAFT -> SUBMITTED -> APPROVED -> FULFILLED.
+	// DRAFT, SUBMITTED and APPROVED orders can be CANCELLED, SUBMITTED orders can be REJECTED.
+	Status PurchaseOrderStatus `json:"status"`
 }
 
 // PostPurchaseOrderJSONRequestBody defines body for PostPurchaseOrder for application/json ContentType.
@@ -89,10 +161,10 @@ type PatchPurchaseOrderIdStatusJSONRequestBody PatchPurchaseOrderIdStatusJSONBod
 type ServerInterface interface {
 	// Get list of purchase orders
 	// (GET /purchase-order)
-	GetPurchaseOrder(c *gin.Context)
+	GetPurchaseOrder(c *gin.Context, params GetPurchaseOrderParams)
 	// Create a new purchase order
 	// (POST /purchase-order)
-	PostPurchaseOrder(c *gin.Context)
+	PostPurchaseOrder(c *gin.Context, params PostPurchaseOrderParams)
 	// Get details of a specific purchase order
 	// (GET /purchase-order/{id})
 	GetPurchaseOrderId(c *gin.Context, id openapi_types.UUID)
@@ -113,8 +185,107 @@ type MiddlewareFunc func(c *gin.Context)
 // GetPurchaseOrder operation middleware
 func (siw *ServerInterfaceWrapper) GetPurchaseOrder(c *gin.Context) {
 
+	var err error
+
 	c.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPurchaseOrderParams
+
+	// ------------- Required query parameter "page" -------------
+
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "limit" -------------
+
+	if paramValue := c.Query("limit"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument limit is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "order_date_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order_date_from", c.Request.URL.Query(), &params.OrderDateFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter order_date_from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "order_date_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order_date_to", c.Request.URL.Query(), &params.OrderDateTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter order_date_to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "created_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_by", c.Request.URL.Query(), &params.CreatedBy)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter created_by: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "order_number" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order_number", c.Request.URL.Query(), &params.OrderNumber)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter order_number: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "min_total" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_total", c.Request.URL.Query(), &params.MinTotal)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter min_total: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "max_total" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_total", c.Request.URL.Query(), &params.MaxTotal)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter max_total: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -122,14 +293,40 @@ func (siw *ServerInterfaceWrapper) GetPurchaseOrder(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetPurchaseOrder(c)
+	siw.Handler.GetPurchaseOrder(c, params)
 }
 
 // PostPurchaseOrder operation middleware
 func (siw *ServerInterfaceWrapper) PostPurchaseOrder(c *gin.Context) {
 
+	var err error
+
 	c.Set(BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPurchaseOrderParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -137,7 +334,7 @@ func (siw *ServerInterfaceWrapper) PostPurchaseOrder(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostPurchaseOrder(c)
+	siw.Handler.PostPurchaseOrder(c, params)
 }
 
 // GetPurchaseOrderId operation middleware
@@ -226,13 +423,14 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 }
 
 type GetPurchaseOrderRequestObject struct {
+	Params GetPurchaseOrderParams
 }
 
 type GetPurchaseOrderResponseObject interface {
 	VisitGetPurchaseOrderResponse(w http.ResponseWriter) error
 }
 
-type GetPurchaseOrder200JSONResponse []PurchaseOrder
+type GetPurchaseOrder200JSONResponse PurchaseOrderPaginateResponseData
 
 func (response GetPurchaseOrder200JSONResponse) VisitGetPurchaseOrderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -241,6 +439,17 @@ func (response GetPurchaseOrder200JSONResponse) VisitGetPurchaseOrderResponse(w
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetPurchaseOrder400JSONResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+func (response GetPurchaseOrder400JSONResponse) VisitGetPurchaseOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetPurchaseOrder500JSONResponse struct {
 	Message *string `json:"message,omitempty"`
 }
@@ -253,7 +462,8 @@ func (response GetPurchaseOrder500JSONResponse) VisitGetPurchaseOrderResponse(w
 }
 
 type PostPurchaseOrderRequestObject struct {
-	Body *PostPurchaseOrderJSONRequestBody
+	Params PostPurchaseOrderParams
+	Body   *PostPurchaseOrderJSONRequestBody
 }
 
 type PostPurchaseOrderResponseObject interface {
@@ -282,6 +492,28 @@ func (response PostPurchaseOrder400JSONResponse) VisitPostPurchaseOrderResponse(
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostPurchaseOrder409JSONResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+func (response PostPurchaseOrder409JSONResponse) VisitPostPurchaseOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostPurchaseOrder422JSONResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+func (response PostPurchaseOrder422JSONResponse) VisitPostPurchaseOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PostPurchaseOrder500JSONResponse struct {
 	Message *string `json:"message,omitempty"`
 }
@@ -363,6 +595,17 @@ func (response PatchPurchaseOrderIdStatus400JSONResponse) VisitPatchPurchaseOrde
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PatchPurchaseOrderIdStatus403JSONResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+func (response PatchPurchaseOrderIdStatus403JSONResponse) VisitPatchPurchaseOrderIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PatchPurchaseOrderIdStatus404JSONResponse struct {
 	Message *string `json:"message,omitempty"`
 }
@@ -374,6 +617,17 @@ func (response PatchPurchaseOrderIdStatus404JSONResponse) VisitPatchPurchaseOrde
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PatchPurchaseOrderIdStatus409JSONResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+func (response PatchPurchaseOrderIdStatus409JSONResponse) VisitPatchPurchaseOrderIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type PatchPurchaseOrderIdStatus500JSONResponse struct {
 	Message *string `json:"message,omitempty"`
 }
@@ -414,9 +668,11 @@ type strictHandler struct {
 }
 
 // GetPurchaseOrder operation middleware
-func (sh *strictHandler) GetPurchaseOrder(ctx *gin.Context) {
+func (sh *strictHandler) GetPurchaseOrder(ctx *gin.Context, params GetPurchaseOrderParams) {
 	var request GetPurchaseOrderRequestObject
 
+	request.Params = params
+
 	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetPurchaseOrder(ctx, request.(GetPurchaseOrderRequestObject))
 	}
@@ -439,9 +695,11 @@ func (sh *strictHandler) GetPurchaseOrder(ctx *gin.Context) {
 }
 
 // PostPurchaseOrder operation middleware
-func (sh *strictHandler) PostPurchaseOrder(ctx *gin.Context) {
+func (sh *strictHandler) PostPurchaseOrder(ctx *gin.Context, params PostPurchaseOrderParams) {
 	var request PostPurchaseOrderRequestObject
 
+	request.Params = params
+
 	var body PostPurchaseOrderJSONRequestBody
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		ctx.Status(http.StatusBadRequest)
@@ -536,21 +794,36 @@ func (sh *strictHandler) PatchPurchaseOrderIdStatus(ctx *gin.Context, id openapi
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xXTW/bMAz9K4K2Y5pka3fxrd1hyGkBhp2KIFAkOlFhS6pEdcgC//dBsvPhjyZOu7VD",
-	"t5sTUzT5+B6fvaFc50YrUOhosqGOryBn8XLqLV8xB1+tABv+MFYbsCgh3uYWGIKYMwy/Um3zcEUFQ7hA",
-	"mQMdUFwboAl1aKVa0mKwO7NY1854L0VnuLcWFI/BrZsCMjjxfOWzjC0yoAlaDx0PkKJXHRIhjz3vLt5b",
-	"SGlC34326I0q6EY13CYIeUhR5WTWsnX4rTSWMJ6sUYc089BWf5zLM8rni3JyrQCHDL3rvBVy/tQKum9q",
-	"ZNmc5dqrOuxpphnuS6keXQyoN+JsmmzP9KJJMaAW7r20IGhyS2NIrf9dtzUsG70ckG22e4Re3AHHUFF7",
-	"pv+yHozVwnOcnxmuWN7Nqm2ANii1Ojdtderx7NXs5uX0e2a/90yhxEOspUJYlqwuuWOs5NBTBkriWfGv",
-	"IZs2UrVZdw2qMd3uqdTar4N3APRRCYZ6pUp1HIfEwOGdKElUJbmeTuiAPoB1Uiua0A/D8XAct6EBxYyk",
-	"Cb0cjoeXoUiGqyja0bblC711uSVEyIO0WWhhImhCvwDW7TCg54xWrhT/x/E47gCtEMrNyIzJJI8ZRncu",
-	"FLQ116cZSdtEiih6x62MUNOEXpNMOiQ6Jdu2SGzLhcOfziyxvt1ycI4tuwRWdM6qXthEIVjFMuLAPoAl",
-	"YK228agD7m2U2e2GLoBZsNceVzS5nRWzAXU+z5ldlxM41p3RrmNsU+065nbvweGNFutn4NF+JWi4wbFV",
-	"/cT9ed56PLbAGuI/qfF+Gm0RtLFiIlLd0t7HBWcqnqmuxqhEv3V4ksXTGu1IZd6h76tX1NYNE6Ti9BuQ",
-	"+ecIKmFEwY+GzmOixsIebaQoem/tSeQ3sywHDHsjFCND4cEPwvtRfIUovbDOyMEBYqeYNHsme8+whJMc",
-	"FYBMZiVFr16NF42ilEaSaq/EG7GlEmQXnIkRZ4DLVPK+5B3tP8UMQ77qMLHwd4PH37ZfNC/D5t/hmI9+",
-	"cjZsoop7eZ9wnnNwhxUutM6AqX5kKkdCqpdwUmVLfZat/zKL+L8K/sgq+B4nT3AFpORwuRBae6AofgUA",
-	"AP//1On6gHUTAAA=",
+	"H4sIAAAAAAAC/+RZ33PbuBH+V3bQe6R+XJw8VG+O7XTUunce2+lDHVUDkUsRdyTAAMvIOo/+9w4AkhIp",
+	"6kQlHSu9e5JEEAtg99vv24VeWKiyXEmUZNjkhZkwwYy7r3eFDhNu8GcdobYPcq1y1CTQDS+4wXlYaI0y",
+	"XNsHEZpQi5yEkmzC3nODUA3DKkEJlCAoawxW3ECokRNGLGC0zpFNmCEt5JJtAm+aFPF0zjNVSNo3/2hH",
+	"wY+C8LYXu0tu7coiW6BmAXseLNWgfBhhKDKeDq/95+7oQGS50m7NnFPCJmwpKCkWw1BlI5Oo3OR2o6PS",
+	"BNtsAlYeZs7dtFjpzH5jEScckMiw65TVnMW6MacoRKdTdl29NxhhikfWl0Wa8kWKbEK6wI4F8DlMuFzi",
+	"XHPCfY9/lIIMqHgnjHV4c9RQSEHV8OLk4L9GkETUy9GCMHMIr7/8oDFmE/aX0TZXRmWijBpZMiXM2Ka2",
+	"ybXma/tbKvJJczQIzkXzqIxAPyD5OaUHu9BhiFNx2kke/BR7FpHhb0pip+V2kr5iNIs8Ojnlqjm9Um4T",
+	"MI2fC6ExYpMn5l5puLp2bCNsLafsJO6sXkItfsGQ7I724bNHtH82brFp15da7JNcixANrFAjhEp+QU0Y",
+	"wUpQ8l3SS65VVIQ0P/F1ybPuFKxeUM6Zp5otZx22XuJz7hHe0/rngksStIsnIQmXqLek4cL22pwhBZ1n",
+	"4XOQ1X7sGujrgk4Lb904afixGc6d0J9CfHd8KSQnvEeTK2nwmhPfJ8JvEOYuUU5FJqgboTlf4uER8zuw",
+	"7hpqxca/V5nyn6zaTlV+HHXZQ63pTSq9FTGG6zBFS5gcKgz42mvySV7fX354hMGnYjy+QHj4+P6f08fH",
+	"m+v6yeXd3f3P/9p58OHj7Yfp7e3N9bCcHOzM4jLaznBLGAi5hAXC1eVPVzd23u77zVfub/5+c/XoLLOA",
+	"oSwy6x+3CAtYPYsFrFqDBazeDwtYvQYLWGWMzfaTw7KzjJULjiCrU7UzwXkTLu+mLGBfUBvvxh+H4+HY",
+	"FVc5Sp4LNmEXw/HwwsWLEuf4UeXcgapapCU6RFnUchuQacQm7G9ITTBaG5pnSKgNmzy9MGGX/Fygtmnj",
+	"ubiCxRY5Xlo9vH3cY16kxCY/Bh2Q6zZagayP1XG32SbcrlSWcTBoD2RlNxaYRiaAXGMsnoH7B06NYQCx",
+	"0mDno4yEXHo0WMh37NQoTaxzY2zQrLbaRNh98rpU21o8nUZ2quIGmRxadLvReaxV1li9jwj0sEvqq6w2",
+	"ozhdSqVt9JSGRbFGbQJbWq1ByXQNBtH+EhrUSpYpfCBqO3Vn564OiVd7Qw/IdZiAKJeDWqMPe6N+Zbts",
+	"T29mQs4rUu7ypCpsaduuJQ+b489fZW5m09Lrn0Plm/HYfoRKEvruiud5KkJHLKNfjJLbC5uTUNypto4k",
+	"m0G4hFQYV3g3VcT4dM69GfvuJmBvT9xtU9ozNKapudugtYVwf6PveQSW0dA4nXx3xq1MJaGWPAWD+gtq",
+	"QK2VLwEMhoV2NfHTC1sg16gvC0rY5GlmQ2+KLON67eXikONdGZqjzoSxOmXFo1XlDTXyiM1spaJMhxbd",
+	"KXNMjJoHukfSAsuQ23bL8AzhV1yDxjzlvgWLhTYEFXpBSEPII3sARwiW67lUlKBunajK6AS5/1Xm0DTC",
+	"LFdkq8fBP7BJJhl/vkW5tK578+7dPpnMvLyhofcqWn8DEg5fbl6VI83rMJf0ZgjXXqkMkOpoaN1tGH5B",
+	"vfYDhUGzdaySGHySpDp6Xee/lTA4hBs3vR4pDEYgESMDXELVa4NVZOBLbsOxb88XXTknC1g2Yf95uhz8",
+	"mw9+m71cbH7ody922F0nmP3Kfvi0dvf3GtJWeX60Q+rb4bRLhMYqh6r8zaZdnG2+URZaPVTUr5k8SnR3",
+	"jTyuL3S/LyV4O/7r2bbykyJAqYplAoZU+Ktlg1BZTagZAyOowBSA0sCrvbfYtsWGIAwYEmkKC7TUmmsV",
+	"ojGl99+8OduRH5P9vdrbfp5aVSp5yh2NQyTiGDVK+gMp95VLAuAgcbUvdMeU26cQm9klW63l6EVEm979",
+	"5TQ60GG666paY13mH24Ej3HEq5WrPdgnQuIi9Rn/9mwIam1KKoJYFTL6g9Sk3snG3yuZHEMRi/B0mJcF",
+	"6gGQj7b/VeWcwqSjgrWPW3h/qJr710H9/6K4/Ib/5FqlRGnp9WsJU4RWd3ZQuVAqRS77wdIfB8prbiit",
+	"xUWarr+7MuLibFvxrQZZ7dRW+C2r8DRVK4xsRZGjtpAFSoQB0lwase3Iv08qPGdRVmJu66i2S+01nSu8",
+	"wtLvpr7y+z+n8I8uz9zZ/Jm6/iDowd8+X+cV62w2m81/BwAztxOzRSQAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,65 @@
+package purchase_order
+
+import (
+	"errors"
+
+	"github.com/LeHNam/wao-api/constant"
+)
+
+var (
+	ErrInvalidStatus              = errors.New("invalid purchase order status")
+	ErrStatusTransitionNotAllowed = errors.New("status transition is not allowed")
+	ErrStatusTransitionForbidden  = errors.New("user role is not allowed to perform this status transition")
+)
+
+// statusTransitions maps a current status to the statuses it can move to,
+// together with the roles allowed to make that move.
+// FULFILLED, CANCELLED and REJECTED are terminal.
+var statusTransitions = map[PurchaseOrderStatus]map[PurchaseOrderStatus][]constant.Role{
+	DRAFT: {
+		SUBMITTED: {constant.RoleBuyer, constant.RoleAdmin},
+		CANCELLED: {constant.RoleBuyer, constant.RoleAdmin},
+	},
+	SUBMITTED: {
+		APPROVED:  {constant.RoleStaff, constant.RoleAdmin},
+		REJECTED:  {constant.RoleStaff, constant.RoleAdmin},
+		CANCELLED: {constant.RoleBuyer, constant.RoleStaff, constant.RoleAdmin},
+	},
+	APPROVED: {
+		FULFILLED: {constant.RoleStaff, constant.RoleAdmin},
+		CANCELLED: {constant.RoleStaff, constant.RoleAdmin},
+	},
+}
+
+// Valid reports whether s is one of the statuses published in the API spec.
+func (s PurchaseOrderStatus) Valid() bool {
+	switch s {
+	case DRAFT, SUBMITTED, APPROVED, FULFILLED, CANCELLED, REJECTED:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether no further transition is possible from s.
+func (s PurchaseOrderStatus) IsTerminal() bool {
+	return s.Valid() && len(statusTransitions[s]) == 0
+}
+
+// CheckStatusTransition validates moving an order from one status to another for the given role.
+func CheckStatusTransition(from, to PurchaseOrderStatus, role constant.Role) error {
+	if !from.Valid() || !to.Valid() {
+		return ErrInvalidStatus
+	}
+
+	roles, ok := statusTransitions[from][to]
+	if !ok {
+		return ErrStatusTransitionNotAllowed
+	}
+
+	for _, r := range roles {
+		if r == role {
+			return nil
+		}
+	}
+	return ErrStatusTransitionForbidden
+}
@@ -0,0 +1,149 @@
+package purchase_order
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LeHNam/wao-api/helpers/utils"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InsufficientStockError is returned when a product option cannot cover the requested quantity
+type InsufficientStockError struct {
+	ProductOptionID uuid.UUID
+	Requested       int
+	Available       int
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for product option %s: requested %d, available %d",
+		e.ProductOptionID, e.Requested, e.Available)
+}
+
+// quantitiesByOption sums item quantities per product option
+func quantitiesByOption(items []models.PurchaseOrderItem) map[uuid.UUID]int {
+	quantities := make(map[uuid.UUID]int, len(items))
+	for _, item := range items {
+		quantities[item.ProductOptionID] += item.Quantity
+	}
+	return quantities
+}
+
+// lockOptions locks the given product options for the rest of the transaction
+func (s *PurchaseOrderServer) lockOptions(ctx context.Context, tx *gorm.DB, quantities map[uuid.UUID]int) (map[uuid.UUID]models.ProductOption, error) {
+	ids := make([]uuid.UUID, 0, len(quantities))
+	for id := range quantities {
+		ids = append(ids, id)
+	}
+
+	options, err := s.sc.ProductOptionRepo.WithTx(tx).FindForUpdate(ctx, map[string]any{"id IN": ids})
+	if err != nil {
+		return nil, err
+	}
+
+	locked := make(map[uuid.UUID]models.ProductOption, len(options))
+	for _, o := range options {
+		locked[o.ID] = o
+	}
+	for _, id := range ids {
+		if _, ok := locked[id]; !ok {
+			return nil, fmt.Errorf("product option not found: %s", id)
+		}
+	}
+	return locked, nil
+}
+
+// reserveStock reserves the ordered quantities so they can no longer be ordered by someone else
+func (s *PurchaseOrderServer) reserveStock(ctx context.Context, tx *gorm.DB, items []models.PurchaseOrderItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	quantities := quantitiesByOption(items)
+	options, err := s.lockOptions(ctx, tx, quantities)
+	if err != nil {
+		return err
+	}
+
+	for id, qty := range quantities {
+		if available := options[id].Available(); available < qty {
+			return &InsufficientStockError{ProductOptionID: id, Requested: qty, Available: available}
+		}
+	}
+
+	movements := make([]models.StockMovement, 0, len(quantities))
+	for id, qty := range quantities {
+		err = s.sc.ProductOptionRepo.WithTx(tx).Update(ctx, id, map[string]any{
+			"reserved": gorm.Expr("reserved + ?", qty),
+		})
+		if err != nil {
+			return err
+		}
+		movements = append(movements, newStockMovement(ctx, id, 0, qty, models.StockReasonPOReservation, items[0].PurchaseOrderID))
+	}
+	return s.sc.StockMovementRepo.WithTx(tx).CreateMany(ctx, movements)
+}
+
+// applyStockTransition moves stock of an order's items when the order changes status:
+// approving turns the reservation into a hard decrement, cancelling or rejecting releases it,
+// and cancelling an approved order puts the decremented quantity back on hand.
+func (s *PurchaseOrderServer) applyStockTransition(ctx context.Context, tx *gorm.DB, orderID uuid.UUID, from, to PurchaseOrderStatus) error {
+	// sign of the quantity and reserved change per ordered unit
+	var delta, reservedDelta int
+	var reason string
+	switch {
+	case to == APPROVED:
+		delta, reservedDelta, reason = -1, -1, models.StockReasonPOFulfilment
+	case (to == CANCELLED || to == REJECTED) && (from == DRAFT || from == SUBMITTED):
+		delta, reservedDelta, reason = 0, -1, models.StockReasonPOReservation
+	case to == CANCELLED && from == APPROVED:
+		delta, reservedDelta, reason = 1, 0, models.StockReasonReturn
+	default:
+		return nil
+	}
+
+	items, err := s.sc.PurchaseOrderItemRepo.WithTx(tx).Find(ctx, map[string]any{"purchase_order_id": orderID}, []string{}, 0, 0, nil)
+	if err != nil {
+		return err
+	}
+
+	quantities := quantitiesByOption(items)
+	options, err := s.lockOptions(ctx, tx, quantities)
+	if err != nil {
+		return err
+	}
+
+	movements := make([]models.StockMovement, 0, len(quantities))
+	for id, qty := range quantities {
+		if to == APPROVED && options[id].Quantity < qty {
+			return &InsufficientStockError{ProductOptionID: id, Requested: qty, Available: options[id].Quantity}
+		}
+		err = s.sc.ProductOptionRepo.WithTx(tx).Update(ctx, id, map[string]any{
+			"quantity": gorm.Expr("quantity + ?", delta*qty),
+			"reserved": gorm.Expr("reserved + ?", reservedDelta*qty),
+		})
+		if err != nil {
+			return err
+		}
+		movements = append(movements, newStockMovement(ctx, id, delta*qty, reservedDelta*qty, reason, orderID))
+	}
+	return s.sc.StockMovementRepo.WithTx(tx).CreateMany(ctx, movements)
+}
+
+// newStockMovement builds a ledger entry for a stock change caused by a purchase order
+func newStockMovement(ctx context.Context, optionID uuid.UUID, delta, reservedDelta int, reason string, orderID uuid.UUID) models.StockMovement {
+	movement := models.StockMovement{
+		ID:              uuid.New(),
+		ProductOptionID: optionID,
+		Delta:           delta,
+		ReservedDelta:   reservedDelta,
+		Reason:          reason,
+		SourceID:        &orderID,
+	}
+	if userCtx := utils.GetUserFromContext(ctx); userCtx != nil {
+		movement.CreatedBy = userCtx.ID
+	}
+	return movement
+}
@@ -2,21 +2,16 @@ package user
 
 import (
 	"context"
-	"fmt"
+	"github.com/LeHNam/wao-api/constant"
 	svCtx "github.com/LeHNam/wao-api/context"
 	"github.com/LeHNam/wao-api/helpers/utils"
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"time"
 )
 
-type User struct {
-	ID       uuid.UUID `json:"id"`
-	Username string    `json:"username"`
-	Password string    `json:"password"`
-	Token    string    `json:"token"`
-}
-
 type UserServer struct {
 	sc *svCtx.ServiceContext
 }
@@ -30,7 +25,8 @@ func NewUserServer(sc *svCtx.ServiceContext) *UserServer {
 // PostLogin handles the login API
 func (s *UserServer) PostLogin(ctx context.Context, request PostLoginRequestObject) (PostLoginResponseObject, error) {
 	user, err := s.sc.UserRepo.FindOne(ctx, map[string]interface{}{
-		"username": request.Body.Username,
+		"username":           request.Body.Username,
+		"deleted_at IS NULL": nil,
 	}, []string{})
 	if err != nil {
 		s.sc.Log.Error("user not found", zap.Error(err))
@@ -40,33 +36,34 @@ func (s *UserServer) PostLogin(ctx context.Context, request PostLoginRequestObje
 	}
 
 	// Validate password (hash comparison can be added here)
-	valid := utils.CheckPasswordHash(request.Body.Password, user.Password)
+	valid := utils.CheckPasswordHash(request.Body.Password, user.Password.Reveal())
 	if !valid {
-		fmt.Print("pass found", request.Body.Password, user.Password)
-
 		return PostLogin401JSONResponse{
 			Message: utils.Stp("Invalid username or password"),
 		}, nil
 	}
 
-	// Generate JWT token
-	token, err := utils.CreateToken(s.sc.Config.JWT.Secret, jwt.MapClaims{
-		"id":       user.ID,
-		"username": user.Username,
-		"email":    user.Email,
-		"name":     user.Name,
-		"role":     user.Role,
-	})
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PostLogin401JSONResponse{
+			Message: utils.Stp("Internal server error"),
+		}, nil
+	}
+	defer tx.Rollback()
+
+	tokens, _, err := s.issueTokens(ctx, tx, user)
 	if err != nil {
+		s.sc.Log.Error("failed to create token", zap.Error(err))
 		return PostLogin401JSONResponse{
 			Message: utils.Stp("failed to create token"),
 		}, nil
 	}
 
 	// Update user token in the database
-	user.Token = token
-	err = s.sc.UserRepo.Update(ctx, user.ID, map[string]interface{}{
-		"token": token,
+	user.Token = models.Secret(*tokens.Token)
+	err = s.sc.UserRepo.WithTx(tx).Update(ctx, user.ID, map[string]interface{}{
+		"token": user.Token.Reveal(),
 	})
 	if err != nil {
 		s.sc.Log.Error("failed to save token", zap.Error(err))
@@ -75,24 +72,145 @@ func (s *UserServer) PostLogin(ctx context.Context, request PostLoginRequestObje
 		}, nil
 	}
 
-	return PostLogin200JSONResponse{
-		Token: &token,
-	}, nil
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit login", zap.Error(err))
+		return PostLogin401JSONResponse{
+			Message: utils.Stp("Internal server error"),
+		}, nil
+	}
+
+	return PostLogin200JSONResponse(tokens), nil
+}
+
+// PostRefresh handles the refresh token API
+func (s *UserServer) PostRefresh(ctx context.Context, request PostRefreshRequestObject) (PostRefreshResponseObject, error) {
+	invalid := PostRefresh401JSONResponse{
+		Message: utils.Stp("Invalid refresh token"),
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return invalid, nil
+	}
+	defer tx.Rollback()
+
+	stored, err := s.sc.RefreshTokenRepo.WithTx(tx).FindOne(ctx, map[string]interface{}{
+		"token_hash": utils.HashToken(request.Body.RefreshToken),
+	}, []string{})
+	if err != nil {
+		return invalid, nil
+	}
+	stored, err = s.sc.RefreshTokenRepo.WithTx(tx).FirstForUpdate(ctx, stored.ID)
+	if err != nil {
+		return invalid, nil
+	}
+
+	now := time.Now()
+	if stored.RevokedAt != nil {
+		// a rotated token was presented again, it may have leaked: revoke the whole session family,
+		// including the access tokens issued to it
+		err = s.revokeRefreshTokens(ctx, tx, stored.UserID)
+		if err == nil {
+			err = s.revokeAccessTokens(ctx, tx, stored.UserID)
+		}
+		if err == nil {
+			err = tx.Commit().Error
+		}
+		if err != nil {
+			s.sc.Log.Error("failed to revoke refresh tokens", zap.Error(err))
+		}
+		return invalid, nil
+	}
+	if now.After(stored.ExpiresAt) {
+		return invalid, nil
+	}
+
+	user, err := s.sc.UserRepo.First(ctx, stored.UserID)
+	if err != nil || user.DeletedAt != nil {
+		return invalid, nil
+	}
+
+	tokens, newID, err := s.issueTokens(ctx, tx, user)
+	if err != nil {
+		s.sc.Log.Error("failed to create token", zap.Error(err))
+		return invalid, nil
+	}
+
+	err = s.sc.RefreshTokenRepo.WithTx(tx).Update(ctx, stored.ID, map[string]interface{}{
+		"revoked_at":  now,
+		"replaced_by": newID,
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to rotate refresh token", zap.Error(err))
+		return invalid, nil
+	}
+
+	err = s.revokeAccessToken(ctx, tx, stored.AccessTokenID, stored.UserID)
+	if err != nil {
+		s.sc.Log.Error("failed to revoke access token", zap.Error(err))
+		return invalid, nil
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit token refresh", zap.Error(err))
+		return invalid, nil
+	}
+
+	return PostRefresh200JSONResponse(tokens), nil
 }
 
 // PostLogout handles the logout API
 func (s *UserServer) PostLogout(ctx context.Context, request PostLogoutRequestObject) (PostLogoutResponseObject, error) {
 	// Extract token from context (assumes middleware sets it)
-	tokenString, exists := ctx.Value("token").(string)
+	tokenString, exists := ctx.Value("token").(models.Secret)
 	if !exists || tokenString == "" {
 		return PostLogout401JSONResponse{
 			Message: utils.Stp("Unauthorized"),
 		}, nil
 	}
 
-	// Parse the token
-	err := s.sc.UserRepo.UpdateFields(ctx, map[string]interface{}{
-		"token": tokenString,
+	tokenID, _ := ctx.Value("token_id").(uuid.UUID)
+	userCtx := utils.GetUserFromContext(ctx)
+	if tokenID == uuid.Nil || userCtx == nil {
+		return PostLogout401JSONResponse{
+			Message: utils.Stp("Unauthorized"),
+		}, nil
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PostLogout401JSONResponse{
+			Message: utils.Stp("Internal server error"),
+		}, nil
+	}
+	defer tx.Rollback()
+
+	err := s.revokeAccessToken(ctx, tx, tokenID, userCtx.ID)
+	if err != nil {
+		s.sc.Log.Error("failed to revoke access token", zap.Error(err))
+		return PostLogout401JSONResponse{
+			Message: utils.Stp("Internal server error"),
+		}, nil
+	}
+
+	// the refresh token issued with this access token ends the session too
+	err = s.sc.RefreshTokenRepo.WithTx(tx).UpdateFields(ctx, map[string]interface{}{
+		"access_token_id":    tokenID,
+		"revoked_at IS NULL": nil,
+	}, map[string]interface{}{
+		"revoked_at": time.Now(),
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to revoke refresh token", zap.Error(err))
+		return PostLogout401JSONResponse{
+			Message: utils.Stp("Internal server error"),
+		}, nil
+	}
+
+	err = s.sc.UserRepo.WithTx(tx).UpdateFields(ctx, map[string]interface{}{
+		"token": tokenString.Reveal(),
 	}, map[string]interface{}{
 		"token": "",
 	})
@@ -103,7 +221,363 @@ func (s *UserServer) PostLogout(ctx context.Context, request PostLogoutRequestOb
 		}, nil
 	}
 
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit logout", zap.Error(err))
+		return PostLogout401JSONResponse{
+			Message: utils.Stp("Internal server error"),
+		}, nil
+	}
+
 	return PostLogout200JSONResponse{
 		Message: utils.Stp("Logout successful"),
 	}, nil
 }
+
+// PostUsers handles the create user API
+func (s *UserServer) PostUsers(ctx context.Context, request PostUsersRequestObject) (PostUsersResponseObject, error) {
+	userCtx := utils.GetUserFromContext(ctx)
+
+	role := constant.ParseRole(string(request.Body.Role))
+	if !role.Valid() {
+		return PostUsers400JSONResponse{
+			Message: utils.Stp("invalid role"),
+		}, nil
+	}
+	if err := utils.ValidatePassword(request.Body.Password); err != nil {
+		return PostUsers400JSONResponse{
+			Message: utils.Stp(err.Error()),
+		}, nil
+	}
+
+	existing, err := s.sc.UserRepo.Count(ctx, map[string]interface{}{
+		"OR": []map[string]interface{}{
+			{"username": request.Body.Username},
+			{"email": string(request.Body.Email)},
+		},
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to check existing users", zap.Error(err))
+		return PostUsers400JSONResponse{
+			Message: utils.Stp("failed to create user"),
+		}, nil
+	}
+	if existing > 0 {
+		return PostUsers409JSONResponse{
+			Message: utils.Stp("username or email already exists"),
+		}, nil
+	}
+
+	hash, err := utils.HashPassword(request.Body.Password)
+	if err != nil {
+		s.sc.Log.Error("failed to hash password", zap.Error(err))
+		return PostUsers400JSONResponse{
+			Message: utils.Stp("failed to create user"),
+		}, nil
+	}
+
+	user := &models.User{
+		ID:        uuid.New(),
+		Name:      request.Body.Name,
+		Email:     string(request.Body.Email),
+		Username:  request.Body.Username,
+		Password:  models.Secret(hash),
+		Role:      role,
+		CreatedBy: userCtx.ID,
+		UpdatedBy: userCtx.ID,
+	}
+	err = s.sc.UserRepo.Create(ctx, user)
+	if err != nil {
+		if database.IsDuplicateKeyError(err) {
+			return PostUsers409JSONResponse{
+				Message: utils.Stp("username or email already exists"),
+			}, nil
+		}
+		s.sc.Log.Error("failed to create user", zap.Error(err))
+		return PostUsers400JSONResponse{
+			Message: utils.Stp("failed to create user"),
+		}, nil
+	}
+
+	return PostUsers201JSONResponse(toUserResponse(user)), nil
+}
+
+// GetUsers handles the list users API
+func (s *UserServer) GetUsers(ctx context.Context, request GetUsersRequestObject) (GetUsersResponseObject, error) {
+	cond := map[string]interface{}{}
+	if request.Params.Search != nil && *request.Params.Search != "" {
+		cond["OR"] = []map[string]interface{}{
+			{"name LIKE": *request.Params.Search},
+			{"username LIKE": *request.Params.Search},
+			{"email LIKE": *request.Params.Search},
+		}
+	}
+	if request.Params.Role != nil {
+		cond["role"] = constant.ParseRole(string(*request.Params.Role))
+	}
+	if request.Params.IncludeDeactivated == nil || !*request.Params.IncludeDeactivated {
+		cond["deleted_at IS NULL"] = nil
+	}
+
+	page := request.Params.Page
+	limit := request.Params.Limit
+	if page < 1 || limit < 1 {
+		return GetUsers400JSONResponse{
+			Message: utils.Stp("page and limit must be greater than zero"),
+		}, nil
+	}
+	offset := (page - 1) * limit
+
+	users, err := s.sc.UserRepo.Find(ctx, cond, []string{}, limit, offset, request.Params.Sort)
+	if err != nil {
+		s.sc.Log.Error("failed to list users", zap.Error(err))
+		return GetUsers400JSONResponse{
+			Message: utils.Stp("failed to get list of users"),
+		}, nil
+	}
+	total, _ := s.sc.UserRepo.Count(ctx, cond)
+
+	items := make([]User, 0, len(users))
+	for i := range users {
+		items = append(items, toUserResponse(&users[i]))
+	}
+
+	return GetUsers200JSONResponse{
+		Items: items,
+		Limit: limit,
+		Page:  page,
+		Pages: (int(total) + limit - 1) / limit,
+		Total: int(total),
+	}, nil
+}
+
+// DeleteUsersId handles the deactivate user API
+func (s *UserServer) DeleteUsersId(ctx context.Context, request DeleteUsersIdRequestObject) (DeleteUsersIdResponseObject, error) {
+	userCtx := utils.GetUserFromContext(ctx)
+	if userCtx.ID == request.Id {
+		return DeleteUsersId400JSONResponse{
+			Message: utils.Stp("you can not deactivate yourself"),
+		}, nil
+	}
+
+	user, err := s.sc.UserRepo.First(ctx, request.Id)
+	if err != nil || user.DeletedAt != nil {
+		return DeleteUsersId404JSONResponse{
+			Message: utils.Stp("user not found"),
+		}, nil
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return DeleteUsersId400JSONResponse{
+			Message: utils.Stp("Create DB transaction failed"),
+		}, nil
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	err = s.sc.UserRepo.WithTx(tx).Update(ctx, user.ID, map[string]interface{}{
+		"deleted_at": now,
+		"updated_by": userCtx.ID,
+		"token":      "",
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to deactivate user", zap.Error(err))
+		return DeleteUsersId400JSONResponse{
+			Message: utils.Stp("deactivate user failed"),
+		}, nil
+	}
+
+	err = s.revokeRefreshTokens(ctx, tx, user.ID)
+	if err != nil {
+		s.sc.Log.Error("failed to revoke refresh tokens", zap.Error(err))
+		return DeleteUsersId400JSONResponse{
+			Message: utils.Stp("deactivate user failed"),
+		}, nil
+	}
+
+	err = s.revokeAccessTokens(ctx, tx, user.ID)
+	if err != nil {
+		s.sc.Log.Error("failed to revoke access tokens", zap.Error(err))
+		return DeleteUsersId400JSONResponse{
+			Message: utils.Stp("deactivate user failed"),
+		}, nil
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit user deactivation", zap.Error(err))
+		return DeleteUsersId400JSONResponse{
+			Message: utils.Stp("deactivate user failed"),
+		}, nil
+	}
+
+	return DeleteUsersId204Response{}, nil
+}
+
+// PatchUsersIdRole handles the change user role API
+func (s *UserServer) PatchUsersIdRole(ctx context.Context, request PatchUsersIdRoleRequestObject) (PatchUsersIdRoleResponseObject, error) {
+	userCtx := utils.GetUserFromContext(ctx)
+	if userCtx.ID == request.Id {
+		return PatchUsersIdRole400JSONResponse{
+			Message: utils.Stp("you can not change your own role"),
+		}, nil
+	}
+
+	role := constant.ParseRole(string(request.Body.Role))
+	if !role.Valid() {
+		return PatchUsersIdRole400JSONResponse{
+			Message: utils.Stp("invalid role"),
+		}, nil
+	}
+
+	user, err := s.sc.UserRepo.First(ctx, request.Id)
+	if err != nil || user.DeletedAt != nil {
+		return PatchUsersIdRole404JSONResponse{
+			Message: utils.Stp("user not found"),
+		}, nil
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PatchUsersIdRole400JSONResponse{
+			Message: utils.Stp("Create DB transaction failed"),
+		}, nil
+	}
+	defer tx.Rollback()
+
+	err = s.sc.UserRepo.WithTx(tx).Update(ctx, user.ID, map[string]interface{}{
+		"role":       role,
+		"updated_by": userCtx.ID,
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to change user role", zap.Error(err))
+		return PatchUsersIdRole400JSONResponse{
+			Message: utils.Stp("change role failed"),
+		}, nil
+	}
+
+	// the role is carried in the access token claims, the user gets the new role on the next refresh
+	err = s.revokeAccessTokens(ctx, tx, user.ID)
+	if err != nil {
+		s.sc.Log.Error("failed to revoke access tokens", zap.Error(err))
+		return PatchUsersIdRole400JSONResponse{
+			Message: utils.Stp("change role failed"),
+		}, nil
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit role change", zap.Error(err))
+		return PatchUsersIdRole400JSONResponse{
+			Message: utils.Stp("change role failed"),
+		}, nil
+	}
+	user.Role = role
+
+	return PatchUsersIdRole200JSONResponse(toUserResponse(user)), nil
+}
+
+// GetMe handles the current user API
+func (s *UserServer) GetMe(ctx context.Context, request GetMeRequestObject) (GetMeResponseObject, error) {
+	userCtx := utils.GetUserFromContext(ctx)
+
+	user, err := s.sc.UserRepo.First(ctx, userCtx.ID)
+	if err != nil || user.DeletedAt != nil {
+		return GetMe404JSONResponse{
+			Message: utils.Stp("user not found"),
+		}, nil
+	}
+
+	return GetMe200JSONResponse(toUserResponse(user)), nil
+}
+
+// PutMePassword handles the change own password API
+func (s *UserServer) PutMePassword(ctx context.Context, request PutMePasswordRequestObject) (PutMePasswordResponseObject, error) {
+	userCtx := utils.GetUserFromContext(ctx)
+
+	user, err := s.sc.UserRepo.First(ctx, userCtx.ID)
+	if err != nil || user.DeletedAt != nil {
+		return PutMePassword400JSONResponse{
+			Message: utils.Stp("user not found"),
+		}, nil
+	}
+
+	if !utils.CheckPasswordHash(request.Body.CurrentPassword, user.Password.Reveal()) {
+		return PutMePassword400JSONResponse{
+			Message: utils.Stp("current password is incorrect"),
+		}, nil
+	}
+	if err := utils.ValidatePassword(request.Body.NewPassword); err != nil {
+		return PutMePassword400JSONResponse{
+			Message: utils.Stp(err.Error()),
+		}, nil
+	}
+
+	hash, err := utils.HashPassword(request.Body.NewPassword)
+	if err != nil {
+		s.sc.Log.Error("failed to hash password", zap.Error(err))
+		return PutMePassword400JSONResponse{
+			Message: utils.Stp("change password failed"),
+		}, nil
+	}
+
+	tx := s.sc.DB.Begin().WithContext(ctx)
+	if tx.Error != nil {
+		s.sc.Log.Error(tx.Error.Error())
+		return PutMePassword400JSONResponse{
+			Message: utils.Stp("Create DB transaction failed"),
+		}, nil
+	}
+	defer tx.Rollback()
+
+	err = s.sc.UserRepo.WithTx(tx).Update(ctx, user.ID, map[string]interface{}{
+		"password":   hash,
+		"updated_by": user.ID,
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to change password", zap.Error(err))
+		return PutMePassword400JSONResponse{
+			Message: utils.Stp("change password failed"),
+		}, nil
+	}
+
+	err = s.revokeRefreshTokens(ctx, tx, user.ID)
+	if err != nil {
+		s.sc.Log.Error("failed to revoke refresh tokens", zap.Error(err))
+		return PutMePassword400JSONResponse{
+			Message: utils.Stp("change password failed"),
+		}, nil
+	}
+
+	err = s.revokeAccessTokens(ctx, tx, user.ID)
+	if err != nil {
+		s.sc.Log.Error("failed to revoke access tokens", zap.Error(err))
+		return PutMePassword400JSONResponse{
+			Message: utils.Stp("change password failed"),
+		}, nil
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		s.sc.Log.Error("failed to commit password change", zap.Error(err))
+		return PutMePassword400JSONResponse{
+			Message: utils.Stp("change password failed"),
+		}, nil
+	}
+
+	return PutMePassword200JSONResponse{
+		Message: utils.Stp("Password changed"),
+	}, nil
+}
+
+func toUserResponse(user *models.User) User {
+	return User{
+		Id:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Username:  user.Username,
+		Role:      UserRole(user.Role),
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: &user.UpdatedAt,
+		DeletedAt: user.DeletedAt,
+	}
+}
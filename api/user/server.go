@@ -14,25 +14,121 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
 	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for UserRole.
+const (
+	ADMIN UserRole = "ADMIN"
+	BUYER UserRole = "BUYER"
+	STAFF UserRole = "STAFF"
+)
+
+// MessageResponse defines model for MessageResponse.
+type MessageResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	// ExpiresAt Expiry of the access token
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	RefreshToken *string    `json:"refresh_token,omitempty"`
+	Token        *string    `json:"token,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	CreatedAt time.Time          `json:"created_at"`
+	DeletedAt *time.Time         `json:"deleted_at"`
+	Email     string             `json:"email"`
+	Id        openapi_types.UUID `json:"id"`
+	Name      string             `json:"name"`
+	Role      UserRole           `json:"role"`
+	UpdatedAt *time.Time         `json:"updated_at,omitempty"`
+	Username  string             `json:"username"`
+}
+
+// UserCreateRequest defines model for UserCreateRequest.
+type UserCreateRequest struct {
+	Email    openapi_types.Email `json:"email"`
+	Name     string              `json:"name"`
+	Password string              `json:"password"`
+	Role     UserRole            `json:"role"`
+	Username string              `json:"username"`
+}
+
+// UserPaginateResponseData defines model for UserPaginateResponseData.
+type UserPaginateResponseData struct {
+	Items []User `json:"items"`
+	Limit int    `json:"limit"`
+	Page  int    `json:"page"`
+	Pages int    `json:"pages"`
+	Total int    `json:"total"`
+}
+
+// UserRole defines model for UserRole.
+type UserRole string
+
 // PostLoginJSONBody defines parameters for PostLogin.
 type PostLoginJSONBody struct {
 	Password string `json:"password"`
 	Username string `json:"username"`
 }
 
+// PutMePasswordJSONBody defines parameters for PutMePassword.
+type PutMePasswordJSONBody struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// PostRefreshJSONBody defines parameters for PostRefresh.
+type PostRefreshJSONBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	Page  int     `form:"page" json:"page"`
+	Limit int     `form:"limit" json:"limit"`
+	Sort  *string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Search Search in name, username and email
+	Search             *string   `form:"search,omitempty" json:"search,omitempty"`
+	Role               *UserRole `form:"role,omitempty" json:"role,omitempty"`
+	IncludeDeactivated *bool     `form:"include_deactivated,omitempty" json:"include_deactivated,omitempty"`
+}
+
+// PatchUsersIdRoleJSONBody defines parameters for PatchUsersIdRole.
+type PatchUsersIdRoleJSONBody struct {
+	Role UserRole `json:"role"`
+}
+
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody PostLoginJSONBody
 
+// PutMePasswordJSONRequestBody defines body for PutMePassword for application/json ContentType.
+type PutMePasswordJSONRequestBody PutMePasswordJSONBody
+
+// PostRefreshJSONRequestBody defines body for PostRefresh for application/json ContentType.
+type PostRefreshJSONRequestBody PostRefreshJSONBody
+
+// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
+type PostUsersJSONRequestBody = UserCreateRequest
+
+// PatchUsersIdRoleJSONRequestBody defines body for PatchUsersIdRole for application/json ContentType.
+type PatchUsersIdRoleJSONRequestBody PatchUsersIdRoleJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// User login
@@ -41,6 +137,27 @@ type ServerInterface interface {
 	// User logout
 	// (POST /logout)
 	PostLogout(c *gin.Context)
+	// Get current user
+	// (GET /me)
+	GetMe(c *gin.Context)
+	// Change own password
+	// (PUT /me/password)
+	PutMePassword(c *gin.Context)
+	// Refresh access token
+	// (POST /refresh)
+	PostRefresh(c *gin.Context)
+	// List users
+	// (GET /users)
+	GetUsers(c *gin.Context, params GetUsersParams)
+	// Create user
+	// (POST /users)
+	PostUsers(c *gin.Context)
+	// Deactivate user
+	// (DELETE /users/{id})
+	DeleteUsersId(c *gin.Context, id openapi_types.UUID)
+	// Change user role
+	// (PATCH /users/{id}/role)
+	PatchUsersIdRole(c *gin.Context, id openapi_types.UUID)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -80,6 +197,198 @@ func (siw *ServerInterfaceWrapper) PostLogout(c *gin.Context) {
 	siw.Handler.PostLogout(c)
 }
 
+// GetMe operation middleware
+func (siw *ServerInterfaceWrapper) GetMe(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMe(c)
+}
+
+// PutMePassword operation middleware
+func (siw *ServerInterfaceWrapper) PutMePassword(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PutMePassword(c)
+}
+
+// PostRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostRefresh(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostRefresh(c)
+}
+
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersParams
+
+	// ------------- Required query parameter "page" -------------
+
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "limit" -------------
+
+	if paramValue := c.Query("limit"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument limit is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "search" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "search", c.Request.URL.Query(), &params.Search)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter search: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", c.Request.URL.Query(), &params.Role)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter role: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "include_deactivated" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_deactivated", c.Request.URL.Query(), &params.IncludeDeactivated)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter include_deactivated: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetUsers(c, params)
+}
+
+// PostUsers operation middleware
+func (siw *ServerInterfaceWrapper) PostUsers(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostUsers(c)
+}
+
+// DeleteUsersId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUsersId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteUsersId(c, id)
+}
+
+// PatchUsersIdRole operation middleware
+func (siw *ServerInterfaceWrapper) PatchUsersIdRole(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchUsersIdRole(c, id)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -109,6 +418,13 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 
 	router.POST(options.BaseURL+"/login", wrapper.PostLogin)
 	router.POST(options.BaseURL+"/logout", wrapper.PostLogout)
+	router.GET(options.BaseURL+"/me", wrapper.GetMe)
+	router.PUT(options.BaseURL+"/me/password", wrapper.PutMePassword)
+	router.POST(options.BaseURL+"/refresh", wrapper.PostRefresh)
+	router.GET(options.BaseURL+"/users", wrapper.GetUsers)
+	router.POST(options.BaseURL+"/users", wrapper.PostUsers)
+	router.DELETE(options.BaseURL+"/users/:id", wrapper.DeleteUsersId)
+	router.PATCH(options.BaseURL+"/users/:id/role", wrapper.PatchUsersIdRole)
 }
 
 type PostLoginRequestObject struct {
@@ -119,9 +435,7 @@ type PostLoginResponseObject interface {
 	VisitPostLoginResponse(w http.ResponseWriter) error
 }
 
-type PostLogin200JSONResponse struct {
-	Token *string `json:"token,omitempty"`
-}
+type PostLogin200JSONResponse TokenResponse
 
 func (response PostLogin200JSONResponse) VisitPostLoginResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -170,6 +484,216 @@ func (response PostLogout401JSONResponse) VisitPostLogoutResponse(w http.Respons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetMeRequestObject struct {
+}
+
+type GetMeResponseObject interface {
+	VisitGetMeResponse(w http.ResponseWriter) error
+}
+
+type GetMe200JSONResponse User
+
+func (response GetMe200JSONResponse) VisitGetMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMe404JSONResponse MessageResponse
+
+func (response GetMe404JSONResponse) VisitGetMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutMePasswordRequestObject struct {
+	Body *PutMePasswordJSONRequestBody
+}
+
+type PutMePasswordResponseObject interface {
+	VisitPutMePasswordResponse(w http.ResponseWriter) error
+}
+
+type PutMePassword200JSONResponse MessageResponse
+
+func (response PutMePassword200JSONResponse) VisitPutMePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutMePassword400JSONResponse MessageResponse
+
+func (response PutMePassword400JSONResponse) VisitPutMePasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRefreshRequestObject struct {
+	Body *PostRefreshJSONRequestBody
+}
+
+type PostRefreshResponseObject interface {
+	VisitPostRefreshResponse(w http.ResponseWriter) error
+}
+
+type PostRefresh200JSONResponse TokenResponse
+
+func (response PostRefresh200JSONResponse) VisitPostRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostRefresh401JSONResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+func (response PostRefresh401JSONResponse) VisitPostRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersRequestObject struct {
+	Params GetUsersParams
+}
+
+type GetUsersResponseObject interface {
+	VisitGetUsersResponse(w http.ResponseWriter) error
+}
+
+type GetUsers200JSONResponse UserPaginateResponseData
+
+func (response GetUsers200JSONResponse) VisitGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsers400JSONResponse MessageResponse
+
+func (response GetUsers400JSONResponse) VisitGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersRequestObject struct {
+	Body *PostUsersJSONRequestBody
+}
+
+type PostUsersResponseObject interface {
+	VisitPostUsersResponse(w http.ResponseWriter) error
+}
+
+type PostUsers201JSONResponse User
+
+func (response PostUsers201JSONResponse) VisitPostUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsers400JSONResponse MessageResponse
+
+func (response PostUsers400JSONResponse) VisitPostUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsers409JSONResponse MessageResponse
+
+func (response PostUsers409JSONResponse) VisitPostUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteUsersIdResponseObject interface {
+	VisitDeleteUsersIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteUsersId204Response struct {
+}
+
+func (response DeleteUsersId204Response) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteUsersId400JSONResponse MessageResponse
+
+func (response DeleteUsersId400JSONResponse) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersId404JSONResponse MessageResponse
+
+func (response DeleteUsersId404JSONResponse) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchUsersIdRoleRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *PatchUsersIdRoleJSONRequestBody
+}
+
+type PatchUsersIdRoleResponseObject interface {
+	VisitPatchUsersIdRoleResponse(w http.ResponseWriter) error
+}
+
+type PatchUsersIdRole200JSONResponse User
+
+func (response PatchUsersIdRole200JSONResponse) VisitPatchUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchUsersIdRole400JSONResponse MessageResponse
+
+func (response PatchUsersIdRole400JSONResponse) VisitPatchUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchUsersIdRole404JSONResponse MessageResponse
+
+func (response PatchUsersIdRole404JSONResponse) VisitPatchUsersIdRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// User login
@@ -178,6 +702,27 @@ type StrictServerInterface interface {
 	// User logout
 	// (POST /logout)
 	PostLogout(ctx context.Context, request PostLogoutRequestObject) (PostLogoutResponseObject, error)
+	// Get current user
+	// (GET /me)
+	GetMe(ctx context.Context, request GetMeRequestObject) (GetMeResponseObject, error)
+	// Change own password
+	// (PUT /me/password)
+	PutMePassword(ctx context.Context, request PutMePasswordRequestObject) (PutMePasswordResponseObject, error)
+	// Refresh access token
+	// (POST /refresh)
+	PostRefresh(ctx context.Context, request PostRefreshRequestObject) (PostRefreshResponseObject, error)
+	// List users
+	// (GET /users)
+	GetUsers(ctx context.Context, request GetUsersRequestObject) (GetUsersResponseObject, error)
+	// Create user
+	// (POST /users)
+	PostUsers(ctx context.Context, request PostUsersRequestObject) (PostUsersResponseObject, error)
+	// Deactivate user
+	// (DELETE /users/{id})
+	DeleteUsersId(ctx context.Context, request DeleteUsersIdRequestObject) (DeleteUsersIdResponseObject, error)
+	// Change user role
+	// (PATCH /users/{id}/role)
+	PatchUsersIdRole(ctx context.Context, request PatchUsersIdRoleRequestObject) (PatchUsersIdRoleResponseObject, error)
 }
 
 type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
@@ -250,19 +795,252 @@ func (sh *strictHandler) PostLogout(ctx *gin.Context) {
 	}
 }
 
+// GetMe operation middleware
+func (sh *strictHandler) GetMe(ctx *gin.Context) {
+	var request GetMeRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMe(ctx, request.(GetMeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMe")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetMeResponseObject); ok {
+		if err := validResponse.VisitGetMeResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutMePassword operation middleware
+func (sh *strictHandler) PutMePassword(ctx *gin.Context) {
+	var request PutMePasswordRequestObject
+
+	var body PutMePasswordJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutMePassword(ctx, request.(PutMePasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutMePassword")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PutMePasswordResponseObject); ok {
+		if err := validResponse.VisitPutMePasswordResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostRefresh operation middleware
+func (sh *strictHandler) PostRefresh(ctx *gin.Context) {
+	var request PostRefreshRequestObject
+
+	var body PostRefreshJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostRefresh(ctx, request.(PostRefreshRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostRefresh")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostRefreshResponseObject); ok {
+		if err := validResponse.VisitPostRefreshResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUsers operation middleware
+func (sh *strictHandler) GetUsers(ctx *gin.Context, params GetUsersParams) {
+	var request GetUsersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsers(ctx, request.(GetUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsers")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetUsersResponseObject); ok {
+		if err := validResponse.VisitGetUsersResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostUsers operation middleware
+func (sh *strictHandler) PostUsers(ctx *gin.Context) {
+	var request PostUsersRequestObject
+
+	var body PostUsersJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUsers(ctx, request.(PostUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUsers")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostUsersResponseObject); ok {
+		if err := validResponse.VisitPostUsersResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteUsersId operation middleware
+func (sh *strictHandler) DeleteUsersId(ctx *gin.Context, id openapi_types.UUID) {
+	var request DeleteUsersIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUsersId(ctx, request.(DeleteUsersIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUsersId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteUsersIdResponseObject); ok {
+		if err := validResponse.VisitDeleteUsersIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PatchUsersIdRole operation middleware
+func (sh *strictHandler) PatchUsersIdRole(ctx *gin.Context, id openapi_types.UUID) {
+	var request PatchUsersIdRoleRequestObject
+
+	request.Id = id
+
+	var body PatchUsersIdRoleJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchUsersIdRole(ctx, request.(PatchUsersIdRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchUsersIdRole")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PatchUsersIdRoleResponseObject); ok {
+		if err := validResponse.VisitPatchUsersIdRoleResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RUX2/TMBD/Kta98BJl6QZI5G1MAqVCMAEFpKoPrnNt3CW28Z0H3ZTvjuz0zzo6gTZ4",
-	"c+zc735/fL4FZTtnDRomKG+BUAWvef1JNdhh2pqj9OjPAzf7rzfWd5KhhPHXz5ABpb+h3JxCBrx28bth",
-	"dtD3fQbaLGysr5GU1461NVDC+WUlFtaLQOhFa5faCGnquLKBI4zmNuJM4nmkgIa1krFanF9WkME1ehqw",
-	"RnmRF9BnYB0a6TSUcJYX+Rlk4CQ3SctJ6hFXzhIf4bNvgUIOtCIhjxy8EVKwvUKTQ2riE4+qhhIuLfG7",
-	"BJ2Bx+8BiV/beh0bKGsYTeolnWs37E9WZBOPZJ1MjHzEZD247iTRD+vruMafsnPJh+3u6DTKWmxT2P28",
-	"M57Ya7OMbkQNRsZ07gKtbGNqi78X9IMA7bGGcrqvzvZNZrsiO1+h4iHgfRX7gGmDnDU0yDktiieYkVw/",
-	"FIDrcTN/q/QHPa4mN9Xova6oMh9fqIvqZXXlvn25GL/K8/yowCPsD69BilJQUAqJFqGNPj4vRk9Q0CGR",
-	"XN4LoTLXstW12JosrBcPR/k3xLeIymMd77FsKRVS6Drp19tJGqYgHpxsRu3BidggxnngBhPVZ/SHMRhm",
-	"9x/mf9S9odHdlB6b9T2Y/xP2xMjAjfX6Bh8Z7gFCSnXzWEM5PXymp7N+diz0GEzf9/2vAAAA//9r4/e5",
-	"9gUAAA==",
+	"H4sIAAAAAAAC/9RZbVPbPhL/KhrdzdwbN4E/vZtr3lFoO2FKjwlwD8MwjLA3iVpbcqU1kDL57jcrWcRO",
+	"ZJJQoP++imNJq3367ZPveaqLUitQaPngntt0CoVwj8dgrZjACGyplQV6VRpdgkEJbkPhN9AjzkrgA27R",
+	"SDXh83kS3ujrr5Ainyf8TH8D1U0M7kppwF4JpH8Z2NTIEqVWfMA/0NqM6THDKTCRpmAtQ6LHEz7WpqBD",
+	"PBMIb1AWwJNlfhJuYGzATq/8qVWOE/6wAneiKHNahNnR9PpTKv8lj4bnP4a7X+TQDtXo7+nB8B/Db+V/",
+	"/31w9K7X663eF5P/3IJZFTs1IBCyWuzNZMkghzVnVJXn4pqEQFNBhAYUQuZRPcisRbWqZBZjQokCoueN",
+	"zt3CXw2M+YD/pb9wsH7tXX3SxYj2zRNeldnWGqgsmA4GnK2/V9JAxgcX3DHvtgaZG6drZpOmFS47THfg",
+	"tozgewUWI+4b9PkgQLitU3OFVJ9BTXDKB7uRbaWw9labtjUeXibPoPiGEhu87CVrVNqtzQZ3jpkuXZ6I",
+	"iVROmz4aHAoUqyqVCEX7YZ1kfIE7YYyY0f9cFhIbfiIVwsRvLdvBa2nFxpdQo8hjS0ta8vsCKf/LAztJ",
+	"LVOXgka1KUFVBRHbPzwefuEJPz3b//iRJ/z9+f8+jPhlzFIW0spInJ2SXrwQ1yAMmP0Kp4t/H4NHHf3n",
+	"jCc+7BMlv7pwryliyedEWKqxXg3N+ydDNtaGkRewXE+kYkJl9KQrkhMlkihOKkYsgEKZCjrN9k+GPOE3",
+	"YKyntdvb6e2QCnQJSpSSD/heb6e357SHUydL391BT6W2kVTRuAKY8GwRQwawMooJnzYoZJOrOT6GGR/w",
+	"E23xsyPtzQgW3+ts5kK0VgjK3SXKMq+573+1Wi0y5qr7NvG7SCnh7e4fe83c9RiumzhdEPqqpyrTwNeh",
+	"NQbPVbdrn6Kc4V54eDpx/tjZ2UoZj2G1XQq4y9tWdJZgtnKZflzlpIa3O7s/YY1GrbLQ4VDdiFxmLOiI",
+	"acO6LTGPaq3NeKCYGsjIDUVu3UFbFYUwswAE78S00K+R0unQNUVyZyp+iNW/2TVe7KH3U+bbQHv+oqaV",
+	"nqSyVTIvY+xzJSqcaiN/wBON26LQjLV8cNGOsheX88uY0ckwzuoezRNworVt+AnwGPgLos9nylXxDipj",
+	"QKFzMW+Dt89253IvEdMu6UhpZGNdqS31+wmQpU32E45iYkP845e10vvNoFxWEbgdTIWaeKiFvaHvaF7Q",
+	"YyPfUHgkWpdjKuWbmKzVolg6L/KcWbCU6CwTBpiBG/0NsgiEKzyGk0Yh9SzJqOb9auuiUsHttoeWEtDK",
+	"1UtEf3Uy2sA1gzlY6rwj8+h4VRa+wO3CITMN1kGlEJhOvbPqXKYzSmDBTR92S8tujVaT7SBVA0HfqlZK",
+	"XEVV3Vl3Z7APd15tTDDTBI2rGwVTcNvCS4+dTWFpp7TMaKQWLXHS6jxjWgFLhXJ6uHaJMWNiIqTqsRMD",
+	"lrKvmjARDi5RdDtrFFoGN2BmAaAB7yRjsgRlqdK8yqK41RbrmPBsqF03tFhCWnv7n77IcxuCWSB7obQf",
+	"KrKW+X+quEtYCPPahDi+RL5d8oVU0XQlt6VPLmYfqwTO3QYq3Y0oAN3ui3suiZ3vFZhZmG4MQoPZNnDS",
+	"UFYGY1Hl2Jw2NJrXONHQrm5CdWdzslYb5FEq/E1jFhOx0nJwOQVh0imTihHpZFHKU0IOI4ooC+5gi4nI",
+	"ZbGT9cxo82LLD1y6yNUh5SoDkaK8IdnjqhmL3C5Gedda5yDIjy5fuFaMTmtihby0SLHTOTW7lThlpT9K",
+	"66+fMt+LjNVxeLvU5wSpauS1M17C796Q/e1iKnM5TzoSnx8Z1inOV41nzbqyqKxLXQJZDsIi+ycVGEak",
+	"SArMNaUvlTHSFyUrwXJArOcZgmVyIjGeh0LUeGoWWucR7VnoRnll98XbF3rP6uDxC7wt5Bmpygqbg4Tu",
+	"as1z+e61m6ww6XDRkYncgMhmDO6kRbtljegdPNpxRZHykPP69zKbe8TkgLCKnVM9RuYXH4oxD5/K2dkV",
+	"fg4jvrNmUiWuyzKb92WP9mGH7m6HpGHWkYFpKtkI5NmjiXLNF5VIGH+7qhbn5M1M8fqOvt+0ADaYITsV",
+	"FvIbsL/Z+OBwIcNTfbkfvr6UBPKI4bpnA8G9m/7o2xzKG0SXOc2BZWOji3rlDj2J4PCRTECc1A488jXL",
+	"6zjxs7Q+W33MWm6E4h+fXrf/6cpTxPEvHCQswTd9mHdJ45p9U38d/I3gW48qnFx1cb4Jfufz/w8A7Ikz",
+	"V/0gAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,103 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/LeHNam/wao-api/helpers/utils"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// refreshTokenBytes is the entropy of a generated refresh token
+const refreshTokenBytes = 32
+
+// issueTokens creates a short-lived access token and a refresh token bound to it
+func (s *UserServer) issueTokens(ctx context.Context, tx *gorm.DB, user *models.User) (TokenResponse, uuid.UUID, error) {
+	now := time.Now()
+	tokenID := uuid.New()
+	expiresAt := now.Add(s.sc.Config.JWT.ExpiredAt)
+
+	token, err := utils.CreateToken(s.sc.Config.JWT.Secret, jwt.MapClaims{
+		"id":       user.ID,
+		"username": user.Username,
+		"email":    user.Email,
+		"name":     user.Name,
+		"role":     user.Role,
+		"jti":      tokenID.String(),
+		"iat":      now.Unix(),
+		"exp":      expiresAt.Unix(),
+	})
+	if err != nil {
+		return TokenResponse{}, uuid.Nil, err
+	}
+
+	refreshToken, err := utils.GenerateRandomToken(refreshTokenBytes)
+	if err != nil {
+		return TokenResponse{}, uuid.Nil, err
+	}
+
+	refreshTokenModel := &models.RefreshToken{
+		ID:            uuid.New(),
+		UserID:        user.ID,
+		TokenHash:     models.Secret(utils.HashToken(refreshToken)),
+		AccessTokenID: tokenID,
+		ExpiresAt:     now.Add(s.sc.Config.JWT.RefreshExpiredAt),
+	}
+	err = s.sc.RefreshTokenRepo.WithTx(tx).Create(ctx, refreshTokenModel)
+	if err != nil {
+		return TokenResponse{}, uuid.Nil, err
+	}
+
+	return TokenResponse{
+		Token:        &token,
+		RefreshToken: &refreshToken,
+		ExpiresAt:    &expiresAt,
+	}, refreshTokenModel.ID, nil
+}
+
+// revokeAccessToken adds an access token to the revocation list
+func (s *UserServer) revokeAccessToken(ctx context.Context, tx *gorm.DB, tokenID, userID uuid.UUID) error {
+	exists, err := s.sc.RevokedTokenRepo.WithTx(tx).Count(ctx, map[string]any{"id": tokenID})
+	if err != nil || exists > 0 {
+		return err
+	}
+	return s.sc.RevokedTokenRepo.WithTx(tx).Create(ctx, &models.RevokedToken{
+		ID:     tokenID,
+		UserID: userID,
+		// the token can not outlive its configured lifetime, so the entry is useless after that
+		ExpiresAt: time.Now().Add(s.sc.Config.JWT.ExpiredAt),
+	})
+}
+
+// revokeAccessTokens revokes the access tokens of a user that have not expired yet, so a change to the
+// user takes effect at once instead of when the tokens expire. Every access token is issued together
+// with a refresh token, the live ones are those issued within the access token lifetime.
+func (s *UserServer) revokeAccessTokens(ctx context.Context, tx *gorm.DB, userID uuid.UUID) error {
+	issued, err := s.sc.RefreshTokenRepo.WithTx(tx).Find(ctx, map[string]any{
+		"user_id": userID,
+		"created_at" + database.CONDITION_GREATER_THAN: time.Now().Add(-s.sc.Config.JWT.ExpiredAt),
+	}, []string{"access_token_id"}, 0, 0, nil)
+	if err != nil {
+		return err
+	}
+	for _, token := range issued {
+		if err := s.revokeAccessToken(ctx, tx, token.AccessTokenID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revokeRefreshTokens ends every session of a user
+func (s *UserServer) revokeRefreshTokens(ctx context.Context, tx *gorm.DB, userID uuid.UUID) error {
+	return s.sc.RefreshTokenRepo.WithTx(tx).UpdateFields(ctx, map[string]any{
+		"user_id":            userID,
+		"revoked_at IS NULL": nil,
+	}, map[string]any{
+		"revoked_at": time.Now(),
+	})
+}
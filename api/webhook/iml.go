@@ -0,0 +1,252 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	svCtx "github.com/LeHNam/wao-api/context"
+	"github.com/LeHNam/wao-api/helpers/utils"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type WebhookServer struct {
+	sc *svCtx.ServiceContext
+}
+
+func NewWebhookServer(sc *svCtx.ServiceContext) *WebhookServer {
+	return &WebhookServer{
+		sc: sc,
+	}
+}
+
+// PostWebhooks handles the create webhook subscription API
+func (s *WebhookServer) PostWebhooks(ctx context.Context, request PostWebhooksRequestObject) (PostWebhooksResponseObject, error) {
+	target, err := url.Parse(request.Body.Url)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return PostWebhooks400JSONResponse{
+			Message: utils.Stp("url must be an absolute http or https URL"),
+		}, nil
+	}
+
+	secret := ""
+	if request.Body.Secret != nil {
+		secret = *request.Body.Secret
+	} else {
+		secret, err = utils.GenerateRandomToken(32)
+		if err != nil {
+			s.sc.Log.Error("failed to generate webhook secret", zap.Error(err))
+			return PostWebhooks400JSONResponse{
+				Message: utils.Stp("create webhook failed"),
+			}, nil
+		}
+	}
+
+	eventTypes, err := json.Marshal(uniqueEventTypes(request.Body.EventTypes))
+	if err != nil {
+		return PostWebhooks400JSONResponse{
+			Message: utils.Stp("invalid event types"),
+		}, nil
+	}
+
+	active := true
+	if request.Body.Active != nil {
+		active = *request.Body.Active
+	}
+
+	userCtx := utils.GetUserFromContext(ctx)
+	subscription := &models.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        target.String(),
+		Secret:     models.Secret(secret),
+		EventTypes: eventTypes,
+		Active:     active,
+		CreatedBy:  userCtx.ID,
+		UpdatedBy:  userCtx.ID,
+	}
+	if err := s.sc.WebhookRepo.Create(ctx, subscription); err != nil {
+		s.sc.Log.Error("failed to create webhook subscription", zap.Error(err))
+		return PostWebhooks400JSONResponse{
+			Message: utils.Stp("create webhook failed"),
+		}, nil
+	}
+
+	response := toWebhookSubscriptionResponse(subscription)
+	return PostWebhooks201JSONResponse{
+		Active:     response.Active,
+		CreatedAt:  response.CreatedAt,
+		EventTypes: response.EventTypes,
+		Id:         response.Id,
+		Secret:     subscription.Secret.Reveal(),
+		UpdatedAt:  response.UpdatedAt,
+		Url:        response.Url,
+	}, nil
+}
+
+// GetWebhooks handles the list webhook subscriptions API
+func (s *WebhookServer) GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error) {
+	sort := "-created_at"
+	subscriptions, err := s.sc.WebhookRepo.Find(ctx, map[string]interface{}{
+		"deleted_at IS NULL": nil,
+	}, []string{}, 0, 0, &sort)
+	if err != nil {
+		s.sc.Log.Error("failed to list webhook subscriptions", zap.Error(err))
+		return GetWebhooks500JSONResponse{
+			Message: utils.Stp("failed to get list of webhooks"),
+		}, nil
+	}
+
+	items := make(GetWebhooks200JSONResponse, 0, len(subscriptions))
+	for i := range subscriptions {
+		items = append(items, toWebhookSubscriptionResponse(&subscriptions[i]))
+	}
+	return items, nil
+}
+
+// DeleteWebhooksId handles the delete webhook subscription API
+func (s *WebhookServer) DeleteWebhooksId(ctx context.Context, request DeleteWebhooksIdRequestObject) (DeleteWebhooksIdResponseObject, error) {
+	subscription, err := s.sc.WebhookRepo.First(ctx, request.Id)
+	if err != nil || subscription.DeletedAt != nil {
+		return DeleteWebhooksId404JSONResponse{
+			Message: utils.Stp("webhook not found"),
+		}, nil
+	}
+
+	userCtx := utils.GetUserFromContext(ctx)
+	err = s.sc.WebhookRepo.Update(ctx, subscription.ID, map[string]interface{}{
+		"deleted_at": time.Now(),
+		"active":     false,
+		"updated_by": userCtx.ID,
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to delete webhook subscription", zap.Error(err))
+		return DeleteWebhooksId404JSONResponse{
+			Message: utils.Stp("delete webhook failed"),
+		}, nil
+	}
+
+	return DeleteWebhooksId204Response{}, nil
+}
+
+// GetWebhooksIdDeliveries handles the webhook delivery log API
+func (s *WebhookServer) GetWebhooksIdDeliveries(ctx context.Context, request GetWebhooksIdDeliveriesRequestObject) (GetWebhooksIdDeliveriesResponseObject, error) {
+	if _, err := s.sc.WebhookRepo.First(ctx, request.Id); err != nil {
+		return GetWebhooksIdDeliveries404JSONResponse{
+			Message: utils.Stp("webhook not found"),
+		}, nil
+	}
+
+	page := request.Params.Page
+	limit := request.Params.Limit
+	if page < 1 || limit < 1 {
+		return GetWebhooksIdDeliveries400JSONResponse{
+			Message: utils.Stp("page and limit must be greater than zero"),
+		}, nil
+	}
+	offset := (page - 1) * limit
+
+	cond := map[string]interface{}{
+		"subscription_id": request.Id,
+	}
+	if request.Params.Status != nil {
+		cond["status"] = string(*request.Params.Status)
+	}
+
+	sort := "-created_at"
+	deliveries, err := s.sc.WebhookDeliveryRepo.Find(ctx, cond, []string{}, limit, offset, &sort)
+	if err != nil {
+		s.sc.Log.Error("failed to list webhook deliveries", zap.Error(err))
+		return GetWebhooksIdDeliveries400JSONResponse{
+			Message: utils.Stp("failed to get list of deliveries"),
+		}, nil
+	}
+	total, _ := s.sc.WebhookDeliveryRepo.Count(ctx, cond)
+
+	items := make([]WebhookDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		items = append(items, toWebhookDeliveryResponse(&deliveries[i]))
+	}
+
+	return GetWebhooksIdDeliveries200JSONResponse{
+		Items: items,
+		Limit: limit,
+		Page:  page,
+		Pages: (int(total) + limit - 1) / limit,
+		Total: int(total),
+	}, nil
+}
+
+// PostWebhooksDeliveriesIdRedeliver handles the redeliver API, the delivery is sent again with a fresh retry budget
+func (s *WebhookServer) PostWebhooksDeliveriesIdRedeliver(ctx context.Context, request PostWebhooksDeliveriesIdRedeliverRequestObject) (PostWebhooksDeliveriesIdRedeliverResponseObject, error) {
+	delivery, err := s.sc.WebhookDeliveryRepo.First(ctx, request.Id)
+	if err != nil {
+		return PostWebhooksDeliveriesIdRedeliver404JSONResponse{
+			Message: utils.Stp("delivery not found"),
+		}, nil
+	}
+
+	now := time.Now()
+	err = s.sc.WebhookDeliveryRepo.Update(ctx, delivery.ID, map[string]interface{}{
+		"status":          models.WebhookDeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+	})
+	if err != nil {
+		s.sc.Log.Error("failed to queue webhook redelivery", zap.Error(err))
+		return PostWebhooksDeliveriesIdRedeliver500JSONResponse{
+			Message: utils.Stp("redeliver failed"),
+		}, nil
+	}
+	s.sc.Webhooks.Notify()
+
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	return PostWebhooksDeliveriesIdRedeliver202JSONResponse(toWebhookDeliveryResponse(delivery)), nil
+}
+
+func uniqueEventTypes(eventTypes []WebhookEventType) []WebhookEventType {
+	seen := make(map[WebhookEventType]bool, len(eventTypes))
+	unique := make([]WebhookEventType, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if seen[eventType] {
+			continue
+		}
+		seen[eventType] = true
+		unique = append(unique, eventType)
+	}
+	return unique
+}
+
+func toWebhookSubscriptionResponse(subscription *models.WebhookSubscription) WebhookSubscription {
+	var eventTypes []WebhookEventType
+	_ = json.Unmarshal(subscription.EventTypes, &eventTypes)
+	return WebhookSubscription{
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		EventTypes: eventTypes,
+		Id:         subscription.ID,
+		UpdatedAt:  &subscription.UpdatedAt,
+		Url:        subscription.URL,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery *models.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		Attempts:       delivery.Attempts,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		EventId:        delivery.EventID,
+		EventType:      WebhookEventType(delivery.EventType),
+		Id:             delivery.ID,
+		LastError:      &delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseBody:   &delivery.ResponseBody,
+		ResponseCode:   delivery.ResponseCode,
+		Status:         WebhookDeliveryStatus(delivery.Status),
+		SubscriptionId: delivery.SubscriptionID,
+	}
+}
@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+	svCtx "github.com/LeHNam/wao-api/context"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/LeHNam/wao-api/services/webhook"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeDeliveryRepo keeps deliveries in memory, methods it does not override panic
+type fakeDeliveryRepo struct {
+	database.Repository[models.WebhookDelivery]
+	deliveries map[uuid.UUID]models.WebhookDelivery
+}
+
+func (r *fakeDeliveryRepo) First(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &delivery, nil
+}
+
+func (r *fakeDeliveryRepo) Update(ctx context.Context, id uuid.UUID, updates map[string]any) error {
+	delivery := r.deliveries[id]
+	for column, value := range updates {
+		switch column {
+		case "status":
+			delivery.Status = value.(string)
+		case "attempts":
+			delivery.Attempts = value.(int)
+		case "next_attempt_at":
+			delivery.NextAttemptAt = value.(time.Time)
+		}
+	}
+	r.deliveries[id] = delivery
+	return nil
+}
+
+func TestRedeliverResetsAttempts(t *testing.T) {
+	code := 500
+	failed := models.WebhookDelivery{
+		ID:            uuid.New(),
+		EventID:       uuid.New(),
+		EventType:     "order_created",
+		Status:        models.WebhookDeliveryFailed,
+		Attempts:      8,
+		ResponseCode:  &code,
+		NextAttemptAt: time.Now().Add(-time.Hour),
+	}
+	repo := &fakeDeliveryRepo{deliveries: map[uuid.UUID]models.WebhookDelivery{failed.ID: failed}}
+	cfg := &config.Config{}
+	server := NewWebhookServer(&svCtx.ServiceContext{
+		Config:              cfg,
+		Log:                 zap.NewNop(),
+		Webhooks:            webhook.NewDispatcher(cfg, nil, zap.NewNop()),
+		WebhookDeliveryRepo: repo,
+	})
+
+	before := time.Now()
+	response, err := server.PostWebhooksDeliveriesIdRedeliver(context.Background(), PostWebhooksDeliveriesIdRedeliverRequestObject{Id: failed.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accepted, ok := response.(PostWebhooksDeliveriesIdRedeliver202JSONResponse)
+	if !ok {
+		t.Fatalf("response = %T, want 202", response)
+	}
+	if accepted.Status != WebhookDeliveryStatus(models.WebhookDeliveryPending) || accepted.Attempts != 0 {
+		t.Errorf("response = %+v, want a pending delivery with no attempts", accepted)
+	}
+
+	stored := repo.deliveries[failed.ID]
+	if stored.Status != models.WebhookDeliveryPending || stored.Attempts != 0 || stored.NextAttemptAt.Before(before) {
+		t.Errorf("stored delivery = %+v, want pending, 0 attempts, due now", stored)
+	}
+}
+
+func TestRedeliverNotFound(t *testing.T) {
+	server := NewWebhookServer(&svCtx.ServiceContext{
+		Log:                 zap.NewNop(),
+		WebhookDeliveryRepo: &fakeDeliveryRepo{deliveries: map[uuid.UUID]models.WebhookDelivery{}},
+	})
+
+	response, err := server.PostWebhooksDeliveriesIdRedeliver(context.Background(), PostWebhooksDeliveriesIdRedeliverRequestObject{Id: uuid.New()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := response.(PostWebhooksDeliveriesIdRedeliver404JSONResponse); !ok {
+		t.Errorf("response = %T, want 404", response)
+	}
+}
+
+// TestCreatedSecretSignsDeliveries needs a Postgres database, set TEST_DATABASE_DSN to run it
+func TestCreatedSecretSignsDeliveries(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.WebhookSubscription{}); err != nil {
+		t.Fatal(err)
+	}
+	server := NewWebhookServer(&svCtx.ServiceContext{
+		Log:         zap.NewNop(),
+		WebhookRepo: models.NewWebhookSubscription(db),
+	})
+
+	ctx := context.WithValue(context.Background(), "user", &models.User{ID: uuid.New()})
+	response, err := server.PostWebhooks(ctx, PostWebhooksRequestObject{Body: &PostWebhooksJSONRequestBody{
+		Url:        "https://partner.example.com/hooks",
+		EventTypes: []WebhookEventType{OrderCreated},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, ok := response.(PostWebhooks201JSONResponse)
+	if !ok {
+		t.Fatalf("response = %T, want 201", response)
+	}
+	t.Cleanup(func() {
+		db.Delete(&models.WebhookSubscription{}, "id = ?", created.Id)
+	})
+
+	// the dispatcher signs with the subscription as loaded from the database
+	subscription, err := server.sc.WebhookRepo.First(context.Background(), created.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`{"id":"1","type":"order_created"}`)
+	timestamp := time.Now().Unix()
+	if got, want := webhook.Sign(subscription.Secret.Reveal(), timestamp, body), webhook.Sign(created.Secret, timestamp, body); got != want {
+		t.Errorf("delivery signature %s does not verify with the secret given to the partner (%s)", got, want)
+	}
+}
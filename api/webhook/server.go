@@ -0,0 +1,750 @@
+// Package webhook provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package webhook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+	strictgin "github.com/oapi-codegen/runtime/strictmiddleware/gin"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	FAILED    WebhookDeliveryStatus = "FAILED"
+	PENDING   WebhookDeliveryStatus = "PENDING"
+	SUCCEEDED WebhookDeliveryStatus = "SUCCEEDED"
+)
+
+// Defines values for WebhookEventType.
+const (
+	OrderCreated   WebhookEventType = "order_created"
+	OrderUpdated   WebhookEventType = "order_updated"
+	ProductCreated WebhookEventType = "product_created"
+)
+
+// MessageResponse defines model for MessageResponse.
+type MessageResponse struct {
+	Message *string `json:"message,omitempty"`
+}
+
+// WebhookCreateRequest defines model for WebhookCreateRequest.
+type WebhookCreateRequest struct {
+	Active     *bool              `json:"active,omitempty"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	Secret     *string            `json:"secret,omitempty"`
+	Url        string             `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+
+	// EventId Sent as X-Webhook-Id, the same on every attempt
+	EventId        openapi_types.UUID    `json:"event_id"`
+	EventType      WebhookEventType      `json:"event_type"`
+	Id             openapi_types.UUID    `json:"id"`
+	LastError      *string               `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	ResponseBody   *string               `json:"response_body,omitempty"`
+	ResponseCode   *int                  `json:"response_code"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	SubscriptionId openapi_types.UUID    `json:"subscription_id"`
+}
+
+// WebhookDeliveryPaginateResponseData defines model for WebhookDeliveryPaginateResponseData.
+type WebhookDeliveryPaginateResponseData struct {
+	Items []WebhookDelivery `json:"items"`
+	Limit int               `json:"limit"`
+	Page  int               `json:"page"`
+	Pages int               `json:"pages"`
+	Total int               `json:"total"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// WebhookEventType defines model for WebhookEventType.
+type WebhookEventType string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	Active     bool               `json:"active"`
+	CreatedAt  time.Time          `json:"created_at"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	Id         openapi_types.UUID `json:"id"`
+	UpdatedAt  *time.Time         `json:"updated_at,omitempty"`
+	Url        string             `json:"url"`
+}
+
+// WebhookSubscriptionWithSecret defines model for WebhookSubscriptionWithSecret.
+type WebhookSubscriptionWithSecret struct {
+	Active     bool               `json:"active"`
+	CreatedAt  time.Time          `json:"created_at"`
+	EventTypes []WebhookEventType `json:"event_types"`
+	Id         openapi_types.UUID `json:"id"`
+	Secret     string             `json:"secret"`
+	UpdatedAt  *time.Time         `json:"updated_at,omitempty"`
+	Url        string             `json:"url"`
+}
+
+// GetWebhooksIdDeliveriesParams defines parameters for GetWebhooksIdDeliveries.
+type GetWebhooksIdDeliveriesParams struct {
+	Page   int                    `form:"page" json:"page"`
+	Limit  int                    `form:"limit" json:"limit"`
+	Status *WebhookDeliveryStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody = WebhookCreateRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List webhook subscriptions
+	// (GET /webhooks)
+	GetWebhooks(c *gin.Context)
+	// Create webhook subscription
+	// (POST /webhooks)
+	PostWebhooks(c *gin.Context)
+	// Redeliver a webhook delivery
+	// (POST /webhooks/deliveries/{id}/redeliver)
+	PostWebhooksDeliveriesIdRedeliver(c *gin.Context, id openapi_types.UUID)
+	// Delete webhook subscription
+	// (DELETE /webhooks/{id})
+	DeleteWebhooksId(c *gin.Context, id openapi_types.UUID)
+	// Delivery log of a webhook subscription
+	// (GET /webhooks/{id}/deliveries)
+	GetWebhooksIdDeliveries(c *gin.Context, id openapi_types.UUID, params GetWebhooksIdDeliveriesParams)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhooks(c)
+}
+
+// PostWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooks(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostWebhooks(c)
+}
+
+// PostWebhooksDeliveriesIdRedeliver operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksDeliveriesIdRedeliver(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostWebhooksDeliveriesIdRedeliver(c, id)
+}
+
+// DeleteWebhooksId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhooksId(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteWebhooksId(c, id)
+}
+
+// GetWebhooksIdDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksIdDeliveries(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhooksIdDeliveriesParams
+
+	// ------------- Required query parameter "page" -------------
+
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument page is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "limit" -------------
+
+	if paramValue := c.Query("limit"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument limit is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhooksIdDeliveries(c, id, params)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/webhooks", wrapper.GetWebhooks)
+	router.POST(options.BaseURL+"/webhooks", wrapper.PostWebhooks)
+	router.POST(options.BaseURL+"/webhooks/deliveries/:id/redeliver", wrapper.PostWebhooksDeliveriesIdRedeliver)
+	router.DELETE(options.BaseURL+"/webhooks/:id", wrapper.DeleteWebhooksId)
+	router.GET(options.BaseURL+"/webhooks/:id/deliveries", wrapper.GetWebhooksIdDeliveries)
+}
+
+type GetWebhooksRequestObject struct {
+}
+
+type GetWebhooksResponseObject interface {
+	VisitGetWebhooksResponse(w http.ResponseWriter) error
+}
+
+type GetWebhooks200JSONResponse []WebhookSubscription
+
+func (response GetWebhooks200JSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooks500JSONResponse MessageResponse
+
+func (response GetWebhooks500JSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhooksRequestObject struct {
+	Body *PostWebhooksJSONRequestBody
+}
+
+type PostWebhooksResponseObject interface {
+	VisitPostWebhooksResponse(w http.ResponseWriter) error
+}
+
+type PostWebhooks201JSONResponse WebhookSubscriptionWithSecret
+
+func (response PostWebhooks201JSONResponse) VisitPostWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhooks400JSONResponse MessageResponse
+
+func (response PostWebhooks400JSONResponse) VisitPostWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhooksDeliveriesIdRedeliverRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type PostWebhooksDeliveriesIdRedeliverResponseObject interface {
+	VisitPostWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error
+}
+
+type PostWebhooksDeliveriesIdRedeliver202JSONResponse WebhookDelivery
+
+func (response PostWebhooksDeliveriesIdRedeliver202JSONResponse) VisitPostWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhooksDeliveriesIdRedeliver404JSONResponse MessageResponse
+
+func (response PostWebhooksDeliveriesIdRedeliver404JSONResponse) VisitPostWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhooksDeliveriesIdRedeliver500JSONResponse MessageResponse
+
+func (response PostWebhooksDeliveriesIdRedeliver500JSONResponse) VisitPostWebhooksDeliveriesIdRedeliverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhooksIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteWebhooksIdResponseObject interface {
+	VisitDeleteWebhooksIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteWebhooksId204Response struct {
+}
+
+func (response DeleteWebhooksId204Response) VisitDeleteWebhooksIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteWebhooksId404JSONResponse MessageResponse
+
+func (response DeleteWebhooksId404JSONResponse) VisitDeleteWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksIdDeliveriesRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params GetWebhooksIdDeliveriesParams
+}
+
+type GetWebhooksIdDeliveriesResponseObject interface {
+	VisitGetWebhooksIdDeliveriesResponse(w http.ResponseWriter) error
+}
+
+type GetWebhooksIdDeliveries200JSONResponse WebhookDeliveryPaginateResponseData
+
+func (response GetWebhooksIdDeliveries200JSONResponse) VisitGetWebhooksIdDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksIdDeliveries400JSONResponse MessageResponse
+
+func (response GetWebhooksIdDeliveries400JSONResponse) VisitGetWebhooksIdDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksIdDeliveries404JSONResponse MessageResponse
+
+func (response GetWebhooksIdDeliveries404JSONResponse) VisitGetWebhooksIdDeliveriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List webhook subscriptions
+	// (GET /webhooks)
+	GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error)
+	// Create webhook subscription
+	// (POST /webhooks)
+	PostWebhooks(ctx context.Context, request PostWebhooksRequestObject) (PostWebhooksResponseObject, error)
+	// Redeliver a webhook delivery
+	// (POST /webhooks/deliveries/{id}/redeliver)
+	PostWebhooksDeliveriesIdRedeliver(ctx context.Context, request PostWebhooksDeliveriesIdRedeliverRequestObject) (PostWebhooksDeliveriesIdRedeliverResponseObject, error)
+	// Delete webhook subscription
+	// (DELETE /webhooks/{id})
+	DeleteWebhooksId(ctx context.Context, request DeleteWebhooksIdRequestObject) (DeleteWebhooksIdResponseObject, error)
+	// Delivery log of a webhook subscription
+	// (GET /webhooks/{id}/deliveries)
+	GetWebhooksIdDeliveries(ctx context.Context, request GetWebhooksIdDeliveriesRequestObject) (GetWebhooksIdDeliveriesResponseObject, error)
+}
+
+type StrictHandlerFunc = strictgin.StrictGinHandlerFunc
+type StrictMiddlewareFunc = strictgin.StrictGinMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetWebhooks operation middleware
+func (sh *strictHandler) GetWebhooks(ctx *gin.Context) {
+	var request GetWebhooksRequestObject
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebhooks(ctx, request.(GetWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebhooks")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetWebhooksResponseObject); ok {
+		if err := validResponse.VisitGetWebhooksResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostWebhooks operation middleware
+func (sh *strictHandler) PostWebhooks(ctx *gin.Context) {
+	var request PostWebhooksRequestObject
+
+	var body PostWebhooksJSONRequestBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.Status(http.StatusBadRequest)
+		ctx.Error(err)
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostWebhooks(ctx, request.(PostWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostWebhooks")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostWebhooksResponseObject); ok {
+		if err := validResponse.VisitPostWebhooksResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostWebhooksDeliveriesIdRedeliver operation middleware
+func (sh *strictHandler) PostWebhooksDeliveriesIdRedeliver(ctx *gin.Context, id openapi_types.UUID) {
+	var request PostWebhooksDeliveriesIdRedeliverRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostWebhooksDeliveriesIdRedeliver(ctx, request.(PostWebhooksDeliveriesIdRedeliverRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostWebhooksDeliveriesIdRedeliver")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(PostWebhooksDeliveriesIdRedeliverResponseObject); ok {
+		if err := validResponse.VisitPostWebhooksDeliveriesIdRedeliverResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteWebhooksId operation middleware
+func (sh *strictHandler) DeleteWebhooksId(ctx *gin.Context, id openapi_types.UUID) {
+	var request DeleteWebhooksIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteWebhooksId(ctx, request.(DeleteWebhooksIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteWebhooksId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(DeleteWebhooksIdResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhooksIdResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetWebhooksIdDeliveries operation middleware
+func (sh *strictHandler) GetWebhooksIdDeliveries(ctx *gin.Context, id openapi_types.UUID, params GetWebhooksIdDeliveriesParams) {
+	var request GetWebhooksIdDeliveriesRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx *gin.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebhooksIdDeliveries(ctx, request.(GetWebhooksIdDeliveriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebhooksIdDeliveries")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		ctx.Error(err)
+		ctx.Status(http.StatusInternalServerError)
+	} else if validResponse, ok := response.(GetWebhooksIdDeliveriesResponseObject); ok {
+		if err := validResponse.VisitGetWebhooksIdDeliveriesResponse(ctx.Writer); err != nil {
+			ctx.Error(err)
+		}
+	} else if response != nil {
+		ctx.Error(fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/9RYXW/bOhL9KwR3HxnbST+wELAPaZy2XqRtNk6RCzSGS1tjib0SqZCjNEah/35BUl+2",
+	"5NTuzW3RJ1siORyeOWdmqG90qdJMSZBoaPCNmmUMKXd/34ExPIIrMJmSBuyrTKsMNApwE1I/wf7FdQY0",
+	"oAa1kBEtCla9UYsvsERaMHoDi1ipP880cIQruMvBYNcmX6K4dyZDWPE8QRqgzqG2t1AqAS6tQbgHiXP7",
+	"3q0UCKn7828NKxrQfw2bkw3LYw1LJ87t0mtrsWA0FXLi1x7X23Ct+doOGlhqcH6m/OECZIQxDY5P/uOW",
+	"1c8v2TYCjOY6scvggadZYodixMwEw2HGNUrQg3JksFTp0Dplhl+5ooyulE450oDmWlDWA62Gu1xoCGnw",
+	"ye2yCcVsN/ZjSMQ96HUP7IiQZp4C5WohESLQdvnSxSyccwdE7V/IEY5QpEB7jh/6vR5fJfMk4YsEtoLc",
+	"WPEHE6EnhFlqkaFQkgZ0ChIJN+SPo/JwR5OQEYyBGJ4CUZKAPSopT7aBay5CunMz//pwGnknv7tJwg3O",
+	"QWule3TDqIQHnJc+H4S3LmU6X6hw3Wu6nrFUoTvhDvRbgTfIMd9XVBW7pn6RXZ4v6pDN98Jni91uyraV",
+	"Fis2Yla7yxo6dwHdYPMeWrnkkZAuY3n0xhx5Vz918jkkC1Vb0KKbdxKRCuxXY7aZc7dGdkgYFfKkb2gL",
+	"cT+vMuV/aeUOK4+3B2zTmjkg89Ravjx/P568f0MZnX48Ozs/H5+PKaOvTycX52M66zCB0Y7IWsYyrcJ8",
+	"ifMylpRRpUPQnec8C93zI/anLXo9Vo+6BehH0uJTFa1tvuyZfUo8DnK5LGR7KLVbjFgF4L6ya0fjRmA8",
+	"resvT5IPKxp82gurjaAWbDuqTVV//FTlvK6/s8L3BrkWuJ7avb3hBXAN+jTHuHl6XaH8v5trynyH5Zjk",
+	"RhvEbX9AC2tYyJXqVrzTMBWSpFzyCELy1R+UtLOjISulSdlfELM2jl2MokDXgZTgkNPLCWX0HrTxlo8H",
+	"o8HIBkFlIHkmaECfDUaDZ07/GLuTDcsN3UPkwbOQcrvxJKQBfQN4U81pqo2bfzIa2Z+lkgjSRzPLErF0",
+	"i4dfjFeej+GhstgK9ZYyCteKtGG86QPOLnxxoJOP+bbdO/f4MZEIWvKEGND3oInvCdq8cmRvM+rTrJjZ",
+	"epim3DZx9EIY7CeCDTqPjOVwOW5J/HCkVQLu7en43eQ9ndmioXwfvtVeeWsLIJx8vLogqIjTtRmQc9dX",
+	"lf3dmghDOLn8ML0mRkTSMlNg7BuxlkfESym4lZ+bjm0qIskx1xAQE/OTFy//e5uPRs+WMTyQt+9Oz46m",
+	"b09PXrwkakVuqR9qFl+LFAzyNHMDMPDjtvvxL8oV8HlwK69jKB2w7iqZrIkGzLV1V0iCsTCkIiwjnGgu",
+	"Q5USJcHOj0BantujxSCJ8EZSgQjh4FZStiWES2U2leDuOq/KvuxJ+NV7mSo20xfqHIqOEI+f2ocdGbuH",
+	"8e2ZpKrUBaPPf67wXvGQ6BqyA+Tm0e4V3N56K1iTSYelhgSY4TcRFkMN5RvXh/Tq8v855ODUVQsQFVlY",
+	"etv7UMSFbAnQ3oScbMlkTLgMScbXieLh4FHOjmu3JuFV7ZKtBZqngKCNg0pYf2x9oIxK7kqa6wI2Gcha",
+	"gfte8z/rsPXkqdna9NxdYlRj5M6CXFLz+c+kZu2BVEhWKpfh71mYatYQXsul4uuPScXqw6shAYSuLi5B",
+	"hkJGpJGUrRqdKsQ1OGy9WuQ6VRq6Yhi7TSo5TMJfw/3nO6uyP4uH4lfQdMONFlUPoYjH+AmTqcugTfj3",
+	"aVMnYZPqfkaMWWn0Lvc6KK2Wd+zddutPoces5/7eb7S6se9jdbS/2foTyw9l3erTUA/bR/9Upu/9gLM7",
+	"9wowjEj4CgbJSmiDv7pD+V3V7QtZoiKbiPnfFHpR/DUAgN75KakYAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -11,6 +11,17 @@ import (
 	"github.com/spf13/viper"
 )
 
+// NumberFormat is the format of generated document numbers: <prefix><date>-<counter>
+type NumberFormat struct {
+	Prefix string `mapstructure:"prefix" yaml:"prefix"`
+	// DateFormat is a Go time layout, empty to leave the date out
+	DateFormat string `mapstructure:"date_format" yaml:"date_format"`
+	// Padding is the minimum number of digits of the counter
+	Padding int `mapstructure:"padding" yaml:"padding"`
+	// ResetYearly restarts the counter at 1 every year
+	ResetYearly bool `mapstructure:"reset_yearly" yaml:"reset_yearly"`
+}
+
 // Config holds all configuration
 type Config struct {
 	Database struct {
@@ -25,9 +36,72 @@ type Config struct {
 		Port string `mapstructure:"port" yaml:"port"`
 	} `mapstructure:"server" yaml:"server"`
 	JWT struct {
-		Secret    string        `mapstructure:"secret" yaml:"secret"`
-		ExpiredAt time.Duration `mapstructure:"expires_at" yaml:"expires_at"`
+		Secret           string        `mapstructure:"secret" yaml:"secret"`
+		ExpiredAt        time.Duration `mapstructure:"expires_at" yaml:"expires_at"`
+		RefreshExpiredAt time.Duration `mapstructure:"refresh_expires_at" yaml:"refresh_expires_at"`
 	} `mapstructure:"jwt" yaml:"jwt"`
+	WebSocket struct {
+		// Broadcaster is memory for a single instance or postgres to reach the clients of every instance
+		Broadcaster string `mapstructure:"broadcaster" yaml:"broadcaster"`
+		// AllowedOrigins are checked against the Origin header of the handshake, * allows every origin
+		AllowedOrigins []string `mapstructure:"allowed_origins" yaml:"allowed_origins"`
+		// ReplayBuffer is the number of events retained for clients resuming after a reconnect
+		ReplayBuffer int `mapstructure:"replay_buffer" yaml:"replay_buffer"`
+		// SendBuffer is the number of events queued per client before the slow consumer policy applies
+		SendBuffer int `mapstructure:"send_buffer" yaml:"send_buffer"`
+		// SlowConsumerPolicy is drop or disconnect
+		SlowConsumerPolicy string        `mapstructure:"slow_consumer_policy" yaml:"slow_consumer_policy"`
+		PingInterval       time.Duration `mapstructure:"ping_interval" yaml:"ping_interval"`
+		PongWait           time.Duration `mapstructure:"pong_wait" yaml:"pong_wait"`
+		WriteWait          time.Duration `mapstructure:"write_wait" yaml:"write_wait"`
+	} `mapstructure:"websocket" yaml:"websocket"`
+	Outbox struct {
+		PollInterval time.Duration `mapstructure:"poll_interval" yaml:"poll_interval"`
+		BatchSize    int           `mapstructure:"batch_size" yaml:"batch_size"`
+		// MaxAttempts is the number of failed deliveries after which an event is given up
+		MaxAttempts int `mapstructure:"max_attempts" yaml:"max_attempts"`
+	} `mapstructure:"outbox" yaml:"outbox"`
+	Webhook struct {
+		// Timeout of a single delivery request
+		Timeout      time.Duration `mapstructure:"timeout" yaml:"timeout"`
+		PollInterval time.Duration `mapstructure:"poll_interval" yaml:"poll_interval"`
+		BatchSize    int           `mapstructure:"batch_size" yaml:"batch_size"`
+		MaxAttempts  int           `mapstructure:"max_attempts" yaml:"max_attempts"`
+	} `mapstructure:"webhook" yaml:"webhook"`
+	Currency struct {
+		// Base is the currency product prices are in, exchange rates are quoted against it
+		Base string `mapstructure:"base" yaml:"base"`
+	} `mapstructure:"currency" yaml:"currency"`
+	PurchaseOrder struct {
+		Number NumberFormat `mapstructure:"number" yaml:"number"`
+	} `mapstructure:"purchase_order" yaml:"purchase_order"`
+	Idempotency struct {
+		// TTL is how long the response to an Idempotency-Key is kept for replay
+		TTL time.Duration `mapstructure:"ttl" yaml:"ttl"`
+	} `mapstructure:"idempotency" yaml:"idempotency"`
+	Storage struct {
+		// Driver selects the storage backend, only local is supported for now
+		Driver string `mapstructure:"driver" yaml:"driver"`
+		// PublicURL is the URL stored files are served from, /media is served by the API itself
+		PublicURL string `mapstructure:"public_url" yaml:"public_url"`
+		Local     struct {
+			Dir string `mapstructure:"dir" yaml:"dir"`
+		} `mapstructure:"local" yaml:"local"`
+	} `mapstructure:"storage" yaml:"storage"`
+	ProductImage struct {
+		// MaxSize is the largest accepted upload in bytes
+		MaxSize int64 `mapstructure:"max_size" yaml:"max_size"`
+		// ThumbnailSizes are the bounding boxes in pixels thumbnails are generated for
+		ThumbnailSizes []int `mapstructure:"thumbnail_sizes" yaml:"thumbnail_sizes"`
+		// CacheMaxAge is sent with served images, stored files never change
+		CacheMaxAge time.Duration `mapstructure:"cache_max_age" yaml:"cache_max_age"`
+	} `mapstructure:"product_image" yaml:"product_image"`
+	ProductImport struct {
+		// MaxSize is the largest accepted file in bytes
+		MaxSize int64 `mapstructure:"max_size" yaml:"max_size"`
+		// MaxRows is the largest number of data rows imported at once
+		MaxRows int `mapstructure:"max_rows" yaml:"max_rows"`
+	} `mapstructure:"product_import" yaml:"product_import"`
 }
 
 var config Config
@@ -52,6 +126,36 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("database.password", "admin")
 	viper.SetDefault("database.db_name", "wao")
 	viper.SetDefault("server.port", "8080")
+	viper.SetDefault("jwt.expires_at", "15m")
+	viper.SetDefault("jwt.refresh_expires_at", "720h")
+	viper.SetDefault("websocket.broadcaster", "memory")
+	viper.SetDefault("websocket.replay_buffer", 1000)
+	viper.SetDefault("websocket.send_buffer", 64)
+	viper.SetDefault("websocket.slow_consumer_policy", "disconnect")
+	viper.SetDefault("websocket.ping_interval", "54s")
+	viper.SetDefault("websocket.pong_wait", "60s")
+	viper.SetDefault("websocket.write_wait", "10s")
+	viper.SetDefault("outbox.poll_interval", "1s")
+	viper.SetDefault("outbox.batch_size", 100)
+	viper.SetDefault("outbox.max_attempts", 10)
+	viper.SetDefault("webhook.timeout", "10s")
+	viper.SetDefault("webhook.poll_interval", "2s")
+	viper.SetDefault("webhook.batch_size", 20)
+	viper.SetDefault("webhook.max_attempts", 8)
+	viper.SetDefault("currency.base", "USD")
+	viper.SetDefault("purchase_order.number.prefix", "PO-")
+	viper.SetDefault("purchase_order.number.date_format", "2006")
+	viper.SetDefault("purchase_order.number.padding", 6)
+	viper.SetDefault("purchase_order.number.reset_yearly", true)
+	viper.SetDefault("idempotency.ttl", "24h")
+	viper.SetDefault("storage.driver", "local")
+	viper.SetDefault("storage.public_url", "/media")
+	viper.SetDefault("storage.local.dir", "./data/uploads")
+	viper.SetDefault("product_image.max_size", 10<<20)
+	viper.SetDefault("product_image.thumbnail_sizes", []int{160, 480, 1024})
+	viper.SetDefault("product_image.cache_max_age", "8760h")
+	viper.SetDefault("product_import.max_size", 10<<20)
+	viper.SetDefault("product_import.max_rows", 10000)
 
 	viper.SetConfigType("yaml")
 	viper.SetConfigName(env)
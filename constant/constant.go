@@ -1,3 +1,31 @@
 package constant
 
-const ()
+import "strings"
+
+// Role is a user role stored in User.Role and carried in the JWT "role" claim
+type Role string
+
+const (
+	RoleAdmin Role = "ADMIN"
+	RoleStaff Role = "STAFF"
+	RoleBuyer Role = "BUYER"
+)
+
+// ParseRole normalizes a raw role string, older rows store roles in lower case
+func ParseRole(s string) Role {
+	return Role(strings.ToUpper(strings.TrimSpace(s)))
+}
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	switch r {
+	case RoleAdmin, RoleStaff, RoleBuyer:
+		return true
+	}
+	return false
+}
+
+// IsStaff reports whether r is allowed to work on every user's data
+func (r Role) IsStaff() bool {
+	return r == RoleAdmin || r == RoleStaff
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"github.com/LeHNam/wao-api/models"
 	"github.com/LeHNam/wao-api/services/database"
+	"github.com/LeHNam/wao-api/services/outbox"
+	"github.com/LeHNam/wao-api/services/sequence"
+	"github.com/LeHNam/wao-api/services/storage"
+	"github.com/LeHNam/wao-api/services/webhook"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/LeHNam/wao-api/config"
@@ -20,16 +25,41 @@ type ServiceContext struct {
 	cancel                context.CancelFunc
 	Log                   *zap.Logger
 	shutdown              chan struct{}
+	workers               sync.WaitGroup
+	Outbox                *outbox.Dispatcher
+	Webhooks              *webhook.Dispatcher
+	OrderNumbers          *sequence.Generator
+	Storage               storage.Storage
 	ProductRepo           database.Repository[models.Product]
 	ProductOptionRepo     database.Repository[models.ProductOption]
 	UserRepo              database.Repository[models.User]
 	PurchaseOrderRepo     database.Repository[models.PurchaseOrder]
 	PurchaseOrderItemRepo database.Repository[models.PurchaseOrderItem]
+	StockMovementRepo     database.Repository[models.StockMovement]
+	RefreshTokenRepo      database.Repository[models.RefreshToken]
+	RevokedTokenRepo      database.Repository[models.RevokedToken]
+	OutboxEventRepo       database.Repository[models.OutboxEvent]
+	WebhookRepo           database.Repository[models.WebhookSubscription]
+	WebhookDeliveryRepo   database.Repository[models.WebhookDelivery]
+	IdempotencyKeyRepo    database.Repository[models.IdempotencyKey]
+	ExchangeRateRepo      database.Repository[models.ExchangeRate]
+	CategoryRepo          database.Repository[models.Category]
+	TagRepo               database.Repository[models.Tag]
+	ProductImageRepo      database.Repository[models.ProductImage]
 }
 
 func NewServiceContext(cfg *config.Config, db *gorm.DB, log *zap.Logger) *ServiceContext {
+	store, err := storage.New(cfg)
+	if err != nil {
+		log.Fatal("failed to initialize storage", zap.Error(err))
+	}
+	orderNumbers, err := sequence.NewGenerator(sequence.PurchaseOrder, cfg.PurchaseOrder.Number)
+	if err != nil {
+		log.Fatal("invalid purchase order number format", zap.Error(err))
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
-	return &ServiceContext{
+	sc := &ServiceContext{
 		Config: cfg,
 		DB:     db,
 
@@ -37,21 +67,54 @@ func NewServiceContext(cfg *config.Config, db *gorm.DB, log *zap.Logger) *Servic
 		cancel:                cancel,
 		Log:                   log,
 		shutdown:              make(chan struct{}),
+		Outbox:                outbox.NewDispatcher(cfg, db, log),
+		Webhooks:              webhook.NewDispatcher(cfg, db, log),
+		OrderNumbers:          orderNumbers,
+		Storage:               store,
 		ProductRepo:           models.NewProduct(db),
 		ProductOptionRepo:     models.NewProductOption(db),
 		UserRepo:              models.NewUser(db),
 		PurchaseOrderRepo:     models.NewPurchaseOrder(db),
 		PurchaseOrderItemRepo: models.NewPurchaseOrderItem(db),
+		StockMovementRepo:     models.NewStockMovement(db),
+		RefreshTokenRepo:      models.NewRefreshToken(db),
+		RevokedTokenRepo:      models.NewRevokedToken(db),
+		OutboxEventRepo:       models.NewOutboxEvent(db),
+		WebhookRepo:           models.NewWebhookSubscription(db),
+		WebhookDeliveryRepo:   models.NewWebhookDelivery(db),
+		IdempotencyKeyRepo:    models.NewIdempotencyKey(db),
+		ExchangeRateRepo:      models.NewExchangeRate(db),
+		CategoryRepo:          models.NewCategory(db),
+		TagRepo:               models.NewTag(db),
+		ProductImageRepo:      models.NewProductImage(db),
 	}
+	sc.Outbox.AddSink("webhook", outbox.SinkFunc(sc.Webhooks.Enqueue))
+	return sc
 }
 
 func (sc *ServiceContext) Context() context.Context {
 	return sc.ctx
 }
 
+// Start runs the background workers, they stop when Shutdown is called
+func (sc *ServiceContext) Start() {
+	sc.workers.Add(2)
+	go func() {
+		defer sc.workers.Done()
+		sc.Outbox.Run(sc.ctx)
+	}()
+	go func() {
+		defer sc.workers.Done()
+		sc.Webhooks.Run(sc.ctx)
+	}()
+}
+
 func (sc *ServiceContext) Shutdown() {
 	sc.cancel()
 
+	// let the workers finish their current batch before the database goes away
+	sc.workers.Wait()
+
 	// gracefully shutdown the database connection
 	sqlDB, err := sc.DB.DB()
 	if err == nil {
@@ -2,12 +2,19 @@ package utils
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"github.com/LeHNam/wao-api/config"
+	"github.com/LeHNam/wao-api/constant"
 	"github.com/LeHNam/wao-api/models"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"time"
+	"unicode"
 )
 
 func Stp(s string) *string {
@@ -23,6 +30,31 @@ func HashPassword(password string) (string, error) {
 	return string(bytes), err
 }
 
+// MinPasswordLength is the minimum length accepted by ValidatePassword
+const MinPasswordLength = 8
+
+// ValidatePassword checks a new password against the password policy:
+// at least MinPasswordLength characters with at least one letter and one digit
+func ValidatePassword(password string) error {
+	if len([]rune(password)) < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters long", MinPasswordLength)
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return fmt.Errorf("password must contain at least one letter and one digit")
+	}
+	return nil
+}
+
 func CreateToken(secret string, c jwt.Claims) (string, error) {
 	signingKey := []byte(secret)
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, c)
@@ -63,6 +95,23 @@ func ParseToken(tokenString string, key string) (jwt.MapClaims, error) {
 }
 
 func GetTokenClaims(token string) (*models.User, error) {
+	accessToken, err := ParseAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return accessToken.User, nil
+}
+
+// AccessToken is a verified access token
+type AccessToken struct {
+	User *models.User
+	// ID is the jti claim, used to revoke the token
+	ID        uuid.UUID
+	ExpiresAt time.Time
+}
+
+// ParseAccessToken validates an access token and returns its user, token ID (jti claim) and expiry
+func ParseAccessToken(token string) (*AccessToken, error) {
 	cfg := config.GetConfig()
 	jwtSecret := cfg.JWT.Secret
 	payload, err := ParseToken(token, jwtSecret)
@@ -75,7 +124,7 @@ func GetTokenClaims(token string) (*models.User, error) {
 		Name:     payload["name"].(string),
 		Username: payload["username"].(string),
 		Email:    payload["email"].(string),
-		Role:     payload["role"].(string),
+		Role:     constant.ParseRole(payload["role"].(string)),
 	}
 
 	id, err := uuid.Parse(payload["id"].(string))
@@ -84,7 +133,37 @@ func GetTokenClaims(token string) (*models.User, error) {
 	}
 	user.ID = id
 
-	return &user, nil
+	jti, _ := payload["jti"].(string)
+	tokenID, err := uuid.Parse(jti)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid UUID for jti: %v", err)
+	}
+
+	exp, ok := payload["exp"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("missing exp claim")
+	}
+
+	return &AccessToken{
+		User:      &user,
+		ID:        tokenID,
+		ExpiresAt: time.Unix(int64(exp), 0),
+	}, nil
+}
+
+// GenerateRandomToken returns a URL safe random string with n bytes of entropy
+func GenerateRandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex encoded SHA-256 of a token, used to store tokens without keeping them in clear
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 func GetUserFromContext(ctx context.Context) *models.User {
@@ -3,32 +3,52 @@ package middlewares
 import (
 	"context"
 	"fmt"
+	svCtx "github.com/LeHNam/wao-api/context"
 	"github.com/LeHNam/wao-api/helpers/utils"
+	"github.com/LeHNam/wao-api/models"
+	"net/http"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3filter"
 	ginmiddleware "github.com/oapi-codegen/gin-middleware"
 )
 
-func BearerAuthMiddleware() openapi3filter.AuthenticationFunc {
+func BearerAuthMiddleware(sc *svCtx.ServiceContext) openapi3filter.AuthenticationFunc {
 	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
 
 		token := input.RequestValidationInput.Request.Header.Get("Authorization")
 		token = strings.TrimPrefix(token, "Bearer ")
 
 		if token == "" {
+			ginmiddleware.GetGinContext(ctx).Set(AuthStatusKey, http.StatusUnauthorized)
 			return fmt.Errorf("missing %s header", "X-TOKEN")
 		}
 
-		user, err := utils.GetTokenClaims(token)
+		accessToken, err := AuthenticateToken(ctx, sc, token)
 		if err != nil {
-			return fmt.Errorf("missing or invalid authorization token")
+			ginmiddleware.GetGinContext(ctx).Set(AuthStatusKey, http.StatusUnauthorized)
+			return err
 		}
 
-		fmt.Println("User:", user)
-		ginmiddleware.GetGinContext(ctx).Set("user", user)
-		ginmiddleware.GetGinContext(ctx).Set("token", token)
+		ginmiddleware.GetGinContext(ctx).Set("user", accessToken.User)
+		ginmiddleware.GetGinContext(ctx).Set("token", models.Secret(token))
+		ginmiddleware.GetGinContext(ctx).Set("token_id", accessToken.ID)
 
 		return nil
 	}
 }
+
+// AuthenticateToken verifies an access token and checks it has not been revoked by logout or refresh token rotation
+func AuthenticateToken(ctx context.Context, sc *svCtx.ServiceContext, token string) (*utils.AccessToken, error) {
+	accessToken, err := utils.ParseAccessToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("missing or invalid authorization token")
+	}
+
+	revoked, err := sc.RevokedTokenRepo.Count(ctx, map[string]interface{}{"id": accessToken.ID})
+	if err != nil || revoked > 0 {
+		return nil, fmt.Errorf("authorization token has been revoked")
+	}
+
+	return accessToken, nil
+}
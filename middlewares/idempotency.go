@@ -0,0 +1,224 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	svCtx "github.com/LeHNam/wao-api/context"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed is set on responses replayed from a previous request
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentBodySize is the largest request body read to hash a request, the body is
+	// buffered in memory so multipart uploads are not supported
+	maxIdempotentBodySize = 1 << 20
+	// expired keys are deleted once every pruneEvery stored keys
+	pruneEvery = 100
+)
+
+// idempotencyWriter keeps a copy of the response body so it can be replayed
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware makes mutating requests sent with an Idempotency-Key header safe to retry.
+// The first response is stored for the configured TTL and replayed for a retry with the same key,
+// reusing a key with a different request is rejected with 422. Responses with a 5xx status are not
+// stored so the request can be retried. Multipart bodies are not buffered, a key sent with one is
+// rejected with 400 rather than ignored.
+// It must run after authentication, keys are scoped per user. Responses are stored as is, so it
+// must not be used on routes returning credentials.
+func IdempotencyMiddleware(sc *svCtx.ServiceContext) gin.HandlerFunc {
+	ttl := sc.Config.Idempotency.TTL
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	var stored atomic.Int64
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderIdempotencyKey)
+		if key == "" || !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if c.ContentType() == gin.MIMEMultipartPOSTForm {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "Idempotency-Key is not supported on multipart requests"})
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "Idempotency-Key is too long"})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"message": "request body is too large"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": "failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID := uuid.Nil
+		if user, ok := c.Get("user"); ok {
+			if user, ok := user.(*models.User); ok && user != nil {
+				userID = user.ID
+			}
+		}
+
+		now := time.Now()
+		record := &models.IdempotencyKey{
+			ID:          uuid.New(),
+			UserID:      userID,
+			Key:         key,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: hashRequest(c.Request.Method, c.Request.URL.Path, body),
+			ExpiresAt:   now.Add(ttl),
+		}
+
+		// the outcome is stored even when the client gave up waiting
+		ctx := context.WithoutCancel(c.Request.Context())
+		existing, err := reserveIdempotencyKey(ctx, sc, record)
+		if err != nil {
+			sc.Log.Error("failed to reserve idempotency key", zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "failed to process Idempotency-Key"})
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.RequestHash != record.RequestHash:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"message": "Idempotency-Key was already used with a different request"})
+			case existing.StatusCode == 0:
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"message": "a request with this Idempotency-Key is still being processed"})
+			default:
+				c.Header(HeaderIdempotentReplayed, "true")
+				c.Data(existing.StatusCode, existing.ContentType, existing.ResponseBody)
+				c.Abort()
+			}
+			return
+		}
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		completed := false
+		defer func() {
+			// release the key when the handler panicked or failed so the client can retry
+			if !completed {
+				if err := sc.IdempotencyKeyRepo.Delete(ctx, record.ID); err != nil {
+					sc.Log.Error("failed to release idempotency key", zap.Error(err))
+				}
+			}
+		}()
+
+		c.Next()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		err = sc.IdempotencyKeyRepo.Update(ctx, record.ID, map[string]interface{}{
+			"status_code":   status,
+			"content_type":  writer.Header().Get("Content-Type"),
+			"response_body": writer.body.Bytes(),
+		})
+		if err != nil {
+			sc.Log.Error("failed to store idempotent response", zap.Error(err))
+			return
+		}
+		completed = true
+
+		if stored.Add(1)%pruneEvery == 0 {
+			err = sc.IdempotencyKeyRepo.DeleteWhere(ctx, map[string]interface{}{
+				"expires_at" + database.CONDITION_LESS_THAN: time.Now(),
+			})
+			if err != nil {
+				sc.Log.Error("failed to prune idempotency keys", zap.Error(err))
+			}
+		}
+	}
+}
+
+// reserveIdempotencyKey inserts the key, or returns the stored one when it is already in use.
+// An expired key is replaced.
+func reserveIdempotencyKey(ctx context.Context, sc *svCtx.ServiceContext, record *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	for {
+		result := sc.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil, nil
+		}
+
+		existing, err := sc.IdempotencyKeyRepo.FindOne(ctx, map[string]interface{}{
+			"user_id": record.UserID,
+			"key":     record.Key,
+		}, []string{})
+		if database.IsRecordNotFoundError(err) {
+			// released by a failed request in the meantime
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if existing.ExpiresAt.After(time.Now()) {
+			return existing, nil
+		}
+
+		err = sc.IdempotencyKeyRepo.DeleteWhere(ctx, map[string]interface{}{
+			"id":         existing.ID,
+			"expires_at": existing.ExpiresAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func hashRequest(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,55 @@
+package middlewares
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LeHNam/wao-api/config"
+	svCtx "github.com/LeHNam/wao-api/context"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestIdempotencyRejectsMultipart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handled := false
+	router.POST("/product/import", IdempotencyMiddleware(&svCtx.ServiceContext{Config: &config.Config{}, Log: zap.NewNop()}), func(c *gin.Context) {
+		handled = true
+		c.Status(http.StatusOK)
+	})
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, _ := form.CreateFormFile("file", "products.csv")
+	_, _ = file.Write([]byte("code,name\n"))
+	_ = form.Close()
+
+	request := httptest.NewRequest(http.MethodPost, "/product/import", &body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	request.Header.Set(HeaderIdempotencyKey, "import-1")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+	if handled {
+		t.Error("a multipart request with an Idempotency-Key reached the handler")
+	}
+
+	// without a key the upload goes through
+	body.Reset()
+	form = multipart.NewWriter(&body)
+	_ = form.Close()
+	request = httptest.NewRequest(http.MethodPost, "/product/import", &body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK || !handled {
+		t.Errorf("status = %d, want the request without a key to be handled", recorder.Code)
+	}
+}
@@ -0,0 +1,119 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/LeHNam/wao-api/constant"
+	"github.com/LeHNam/wao-api/helpers/utils"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	ginmiddleware "github.com/oapi-codegen/gin-middleware"
+)
+
+const (
+	// AuthStatusKey holds the HTTP status an authentication failure should be reported with
+	AuthStatusKey = "auth_status"
+
+	// ExtensionRoles lists the roles allowed to call an operation
+	ExtensionRoles = "x-roles"
+	// ExtensionPermissions lists the permissions required to call an operation
+	ExtensionPermissions = "x-permissions"
+)
+
+// rolePermissions maps every role to the permissions it grants, "*" grants everything
+var rolePermissions = map[constant.Role][]string{
+	constant.RoleAdmin: {"*"},
+	constant.RoleStaff: {
+		"product.create",
+		"product.read",
+		"product.update",
+		"product.delete",
+		"category.create",
+		"category.read",
+		"category.update",
+		"category.delete",
+		"purchase_order.create",
+		"purchase_order.read",
+		"purchase_order.update_status",
+		"exchange_rate.read",
+	},
+	constant.RoleBuyer: {
+		"product.read",
+		"category.read",
+		"purchase_order.create",
+		"purchase_order.read",
+		"purchase_order.update_status",
+		"exchange_rate.read",
+	},
+}
+
+// HasPermission reports whether role grants permission
+func HasPermission(role constant.Role, permission string) bool {
+	for _, p := range rolePermissions[role] {
+		if p == "*" || p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RBACMiddleware checks the authenticated user against the x-roles and x-permissions
+// extensions of the requested operation. It must run after BearerAuthMiddleware.
+func RBACMiddleware() openapi3filter.AuthenticationFunc {
+	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+		ginCtx := ginmiddleware.GetGinContext(ctx)
+		user := utils.GetUserFromContext(ginCtx)
+		if user == nil {
+			ginCtx.Set(AuthStatusKey, http.StatusUnauthorized)
+			return fmt.Errorf("missing authenticated user")
+		}
+
+		route := input.RequestValidationInput.Route
+		if route == nil || route.Operation == nil {
+			return nil
+		}
+
+		if roles := extensionValues(route.Operation.Extensions[ExtensionRoles]); len(roles) > 0 {
+			allowed := false
+			for _, r := range roles {
+				if constant.ParseRole(r) == user.Role {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				ginCtx.Set(AuthStatusKey, http.StatusForbidden)
+				return fmt.Errorf("role %s is not allowed to access this resource", user.Role)
+			}
+		}
+
+		for _, p := range extensionValues(route.Operation.Extensions[ExtensionPermissions]) {
+			if !HasPermission(user.Role, p) {
+				ginCtx.Set(AuthStatusKey, http.StatusForbidden)
+				return fmt.Errorf("missing permission %s", p)
+			}
+		}
+
+		return nil
+	}
+}
+
+// extensionValues reads a list of strings from an OpenAPI extension value
+func extensionValues(ext any) []string {
+	switch v := ext.(type) {
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	case string:
+		return []string{v}
+	}
+	return nil
+}
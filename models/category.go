@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Category is a node of the product category tree. Path is the materialized path of the ids from the root
+// down to the category itself, e.g. "/<root id>/<parent id>/<id>/", so the subtree of a category is every
+// category whose path starts with its path. The text_pattern_ops index lets those prefix matches use it.
+type Category struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	ParentID  *uuid.UUID     `json:"parent_id,omitempty" gorm:"type:uuid;index"`
+	Name      string         `json:"name" gorm:"type:varchar(255);not null"`
+	Path      string         `json:"path" gorm:"type:text;not null;index:idx_categories_path_pattern,expression:path text_pattern_ops"`
+	Depth     int            `json:"depth" gorm:"not null;default:0"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// CategoryPath returns the materialized path of a category below the given parent path
+func CategoryPath(parentPath string, id uuid.UUID) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return parentPath + id.String() + "/"
+}
+
+func NewCategory(db *gorm.DB) database.Repository[Category] {
+	return database.NewPostgresRepository[Category](db)
+}
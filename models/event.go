@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Event is a WebSocket event retained so reconnecting clients can replay what they missed.
+// Seq is assigned by the database and is shared by every API instance.
+type Event struct {
+	Seq       int64          `json:"seq" gorm:"primaryKey;autoIncrement"`
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;not null;uniqueIndex"`
+	Type      string         `json:"type" gorm:"type:varchar(64);not null"`
+	Topics    datatypes.JSON `json:"topics" gorm:"type:jsonb"`
+	Owner     uuid.UUID      `json:"owner" gorm:"type:uuid"`
+	Payload   datatypes.JSON `json:"payload" gorm:"type:jsonb"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func NewEvent(db *gorm.DB) database.Repository[Event] {
+	return database.NewPostgresRepository[Event](db)
+}
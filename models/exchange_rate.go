@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// ExchangeRate is the number of Currency units one unit of the base currency buys.
+// Orders keep a copy of the rates they were priced with, changing a rate does not reprice them.
+type ExchangeRate struct {
+	Currency  string          `json:"currency" gorm:"type:varchar(3);primaryKey"`
+	Rate      decimal.Decimal `json:"rate" gorm:"type:numeric(19,8);not null"`
+	CreatedAt time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	UpdatedBy uuid.UUID       `json:"updated_by" gorm:"type:uuid;"`
+}
+
+func NewExchangeRate(db *gorm.DB) database.Repository[ExchangeRate] {
+	return database.NewPostgresRepository[ExchangeRate](db)
+}
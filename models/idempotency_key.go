@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey is the response stored for an Idempotency-Key header, keys are scoped to the user sending them.
+// StatusCode stays 0 while the first request is being processed.
+type IdempotencyKey struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID       uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_key_user"`
+	Key          string    `json:"key" gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_key_user"`
+	Method       string    `json:"method" gorm:"type:varchar(16);not null"`
+	Path         string    `json:"path" gorm:"type:text;not null"`
+	RequestHash  string    `json:"request_hash" gorm:"type:varchar(64);not null"`
+	StatusCode   int       `json:"status_code" gorm:"not null;default:0"`
+	ContentType  string    `json:"content_type" gorm:"type:varchar(255)"`
+	ResponseBody []byte    `json:"-" gorm:"type:bytea"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"not null;index"`
+}
+
+func NewIdempotencyKey(db *gorm.DB) database.Repository[IdempotencyKey] {
+	return database.NewPostgresRepository[IdempotencyKey](db)
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Money amounts are decimal.Decimal: exact in memory, numeric in the database and plain JSON numbers
+// in the API, as the OpenAPI schemas declare them.
+func init() {
+	decimal.MarshalJSONWithoutQuotes = true
+}
+
+// DefaultMinorUnits is the number of decimals of the currencies missing from minorUnits
+const DefaultMinorUnits = 2
+
+// minorUnits lists the ISO 4217 currencies that do not use 2 decimals
+var minorUnits = map[string]int32{
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// MinorUnits returns the number of decimals amounts in currency are rounded to
+func MinorUnits(currency string) int32 {
+	if units, ok := minorUnits[strings.ToUpper(currency)]; ok {
+		return units
+	}
+	return DefaultMinorUnits
+}
+
+// RoundMoney rounds an amount to the minor units of its currency, halves are rounded away from zero
+func RoundMoney(amount decimal.Decimal, currency string) decimal.Decimal {
+	return amount.Round(MinorUnits(currency))
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the change it describes.
+// The outbox dispatcher delivers it to every sink at least once, ID doubles as the dedupe key for sinks.
+type OutboxEvent struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	Type          string         `json:"type" gorm:"type:varchar(64);not null"`
+	Topics        datatypes.JSON `json:"topics" gorm:"type:jsonb"`
+	Owner         uuid.UUID      `json:"owner" gorm:"type:uuid"`
+	Payload       datatypes.JSON `json:"payload" gorm:"type:jsonb"`
+	Attempts      int            `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time      `json:"next_attempt_at" gorm:"not null;index"`
+	LastError     string         `json:"last_error,omitempty" gorm:"type:text"`
+	PublishedAt   *time.Time     `json:"published_at,omitempty" gorm:"index"`
+	FailedAt      *time.Time     `json:"failed_at,omitempty"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func NewOutboxEvent(db *gorm.DB) database.Repository[OutboxEvent] {
+	return database.NewPostgresRepository[OutboxEvent](db)
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ProductImageThumbnail is a downscaled copy of a product image, Size is the bounding box it was scaled into
+type ProductImageThumbnail struct {
+	Size   int    `json:"size"`
+	Key    string `json:"key"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// ProductImage is an uploaded picture of a product stored under Key. Position orders the images of a
+// product, at most one of them is the primary image.
+type ProductImage struct {
+	ID          uuid.UUID                                  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductID   uuid.UUID                                  `json:"product_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_product_images_primary,where:is_primary"`
+	Key         string                                     `json:"key" gorm:"not null"`
+	ContentType string                                     `json:"content_type" gorm:"type:varchar(50);not null"`
+	Size        int64                                      `json:"size" gorm:"not null"`
+	Width       int                                        `json:"width" gorm:"not null"`
+	Height      int                                        `json:"height" gorm:"not null"`
+	Thumbnails  datatypes.JSONSlice[ProductImageThumbnail] `json:"thumbnails" gorm:"type:jsonb;not null"`
+	Position    int                                        `json:"position" gorm:"not null;default:0"`
+	IsPrimary   bool                                       `json:"primary" gorm:"not null;default:false"`
+	CreatedAt   time.Time                                  `gorm:"autoCreateTime" json:"created_at"`
+	CreatedBy   uuid.UUID                                  `json:"created_by" gorm:"type:uuid;"`
+}
+
+func NewProductImage(db *gorm.DB) database.Repository[ProductImage] {
+	return database.NewPostgresRepository[ProductImage](db)
+}
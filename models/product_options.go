@@ -3,19 +3,29 @@ package models
 import (
 	"github.com/LeHNam/wao-api/services/database"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 	"time"
 )
 
+// ProductOption is archived instead of deleted once a purchase order references it,
+// archived options are hidden from the catalogue and can not be ordered.
 type ProductOption struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	ProductID uuid.UUID `gorm:"type:uuid;index;not null" json:"product_id"`
-	Name      string    `gorm:"type:varchar(255);not null" json:"name"`
-	Code      string    `gorm:"type:varchar(100);not null" json:"code"`
-	Quantity  int       `gorm:"default:0" json:"quantity"`
-	Price     float64   `gorm:"type:decimal(10,2);default:0" json:"price"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	ProductID  uuid.UUID       `gorm:"type:uuid;index;not null" json:"product_id"`
+	Name       string          `gorm:"type:varchar(255);not null" json:"name"`
+	Code       string          `gorm:"type:varchar(100);not null" json:"code"`
+	Quantity   int             `gorm:"default:0" json:"quantity"`
+	Reserved   int             `gorm:"default:0;not null" json:"reserved"`
+	Price      decimal.Decimal `gorm:"type:numeric(19,4);default:0" json:"price"`
+	ArchivedAt *time.Time      `gorm:"index" json:"archived_at,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// Available returns the quantity that is neither sold nor reserved by open purchase orders
+func (o ProductOption) Available() int {
+	return o.Quantity - o.Reserved
 }
 
 func NewProductOption(db *gorm.DB) database.Repository[ProductOption] {
@@ -8,14 +8,17 @@ import (
 )
 
 type Product struct {
-	ID        uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
-	Name      string          `gorm:"type:varchar(255);not null" json:"name"`
-	Code      string          `gorm:"type:varchar(100);unique;not null" json:"code"`
-	Img       string          `gorm:"type:text" json:"img"`
-	Options   []ProductOption `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"options"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
-	DeletedAt *time.Time      `json:"deleted_at,omitempty"`
+	ID         uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	Name       string          `gorm:"type:varchar(255);not null" json:"name"`
+	Code       string          `gorm:"type:varchar(100);unique;not null" json:"code"`
+	Img        string          `gorm:"type:text" json:"img"`
+	CategoryID *uuid.UUID      `gorm:"type:uuid;index" json:"category_id,omitempty"`
+	Options    []ProductOption `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"options"`
+	Tags       []Tag           `gorm:"many2many:product_tags;constraint:OnDelete:CASCADE;" json:"tags"`
+	Images     []ProductImage  `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE;" json:"images"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  *time.Time      `json:"deleted_at,omitempty"`
 }
 
 func NewProduct(db *gorm.DB) database.Repository[Product] {
@@ -5,25 +5,34 @@ import (
 
 	"github.com/LeHNam/wao-api/services/database"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
+// PurchaseOrder totals are in Currency. BaseCurrency, BaseTotalAmount and ExchangeRate (Currency units
+// per base unit) are snapshots taken when the order is created, later rate changes do not reprice it.
 type PurchaseOrder struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
-	OrderNumber string         `json:"order_number" gorm:"not null;uniqueIndex"`
-	Status      string         `json:"status" gorm:"not null"`
-	OrderDate   time.Time      `json:"order_date" gorm:"not null"`
-	TotalAmount float64        `json:"total_amount" gorm:"not null"`
-	Currency    string         `json:"currency" gorm:"not null"`
-	Timezone    string         `json:"timezone" gorm:"not null"`
-	Notes       *string        `json:"notes,omitempty"`
-	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	CreatedBy   uuid.UUID      `json:"created_by" gorm:"type:uuid;"`
-	UpdatedBy   uuid.UUID      `json:"updated_by" gorm:"type:uuid;"`
-	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID              uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
+	OrderNumber     string          `json:"order_number" gorm:"not null;uniqueIndex"`
+	Status          string          `json:"status" gorm:"not null"`
+	OrderDate       time.Time       `json:"order_date" gorm:"not null"`
+	TotalAmount     decimal.Decimal `json:"total_amount" gorm:"type:numeric(19,4);not null"`
+	Currency        string          `json:"currency" gorm:"not null"`
+	BaseCurrency    string          `json:"base_currency"`
+	BaseTotalAmount decimal.Decimal `json:"base_total_amount" gorm:"type:numeric(19,4)"`
+	ExchangeRate    decimal.Decimal `json:"exchange_rate" gorm:"type:numeric(19,8)"`
+	Timezone        string          `json:"timezone" gorm:"not null"`
+	Notes           *string         `json:"notes,omitempty"`
+	CreatedAt       time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedBy       uuid.UUID       `json:"created_by" gorm:"type:uuid;"`
+	UpdatedBy       uuid.UUID       `json:"updated_by" gorm:"type:uuid;"`
+	DeletedAt       gorm.DeletedAt  `json:"deleted_at,omitempty" gorm:"index"`
 }
 
+// OpenPurchaseOrderStatuses are the statuses of orders that are not finished yet
+var OpenPurchaseOrderStatuses = []string{"DRAFT", "SUBMITTED", "APPROVED"}
+
 func NewPurchaseOrder(db *gorm.DB) database.Repository[PurchaseOrder] {
 	return database.NewPostgresRepository[PurchaseOrder](db)
 }
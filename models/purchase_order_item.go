@@ -5,25 +5,28 @@ import (
 
 	"github.com/LeHNam/wao-api/services/database"
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
+// PurchaseOrderItem prices are in Currency, converted from the base currency price with ExchangeRate
 type PurchaseOrderItem struct {
-	ID                uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
-	PurchaseOrderID   uuid.UUID      `json:"purchase_order_id" gorm:"not null;index"`
-	ProductID         uuid.UUID      `json:"product_id" gorm:"not null;index"`
-	ProductOptionID   uuid.UUID      `json:"product_option_id" gorm:"not null;index"`
-	ProductName       string         `json:"product_name" gorm:"not null"`
-	ProductOptionName string         `json:"product_option_name" gorm:"not null"`
-	UnitPrice         float64        `json:"unit_price" gorm:"not null"`
-	TotalPrice        float64        `json:"total_price" gorm:"not null"`
-	Currency          string         `json:"currency" gorm:"not null"`
-	Quantity          int            `json:"quantity" gorm:"not null"`
-	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	CreatedBy         uuid.UUID      `json:"created_by" gorm:"type:uuid;"`
-	UpdatedBy         uuid.UUID      `json:"updated_by" gorm:"type:uuid;"`
-	DeletedAt         gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID                uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey"`
+	PurchaseOrderID   uuid.UUID       `json:"purchase_order_id" gorm:"not null;index"`
+	ProductID         uuid.UUID       `json:"product_id" gorm:"not null;index"`
+	ProductOptionID   uuid.UUID       `json:"product_option_id" gorm:"not null;index"`
+	ProductName       string          `json:"product_name" gorm:"not null"`
+	ProductOptionName string          `json:"product_option_name" gorm:"not null"`
+	UnitPrice         decimal.Decimal `json:"unit_price" gorm:"type:numeric(19,4);not null"`
+	TotalPrice        decimal.Decimal `json:"total_price" gorm:"type:numeric(19,4);not null"`
+	Currency          string          `json:"currency" gorm:"not null"`
+	ExchangeRate      decimal.Decimal `json:"exchange_rate" gorm:"type:numeric(19,8)"`
+	Quantity          int             `json:"quantity" gorm:"not null"`
+	CreatedAt         time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedBy         uuid.UUID       `json:"created_by" gorm:"type:uuid;"`
+	UpdatedBy         uuid.UUID       `json:"updated_by" gorm:"type:uuid;"`
+	DeletedAt         gorm.DeletedAt  `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 func NewPurchaseOrderItem(db *gorm.DB) database.Repository[PurchaseOrderItem] {
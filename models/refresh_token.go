@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a rotating refresh token, only the SHA-256 hash of the token is stored
+type RefreshToken struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	TokenHash     Secret     `json:"-" sensitive:"true" gorm:"type:varchar(64);not null;uniqueIndex"`
+	AccessTokenID uuid.UUID  `json:"access_token_id" gorm:"type:uuid;not null;index"`
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	ReplacedBy    *uuid.UUID `json:"replaced_by,omitempty" gorm:"type:uuid"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func NewRefreshToken(db *gorm.DB) database.Repository[RefreshToken] {
+	return database.NewPostgresRepository[RefreshToken](db)
+}
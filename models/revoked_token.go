@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RevokedToken marks an access token (by its jti claim) as no longer valid.
+// Rows can be purged once ExpiresAt has passed since the token is rejected as expired anyway.
+type RevokedToken struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func NewRevokedToken(db *gorm.DB) database.Repository[RevokedToken] {
+	return database.NewPostgresRepository[RevokedToken](db)
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// TagSensitive marks a model field that must never be serialized, e.g. `sensitive:"true"`
+const TagSensitive = "sensitive"
+
+// Redacted is what a Secret prints as in JSON, logs and formatted output
+const Redacted = "[REDACTED]"
+
+// Secret is a string that is stored as is but never shows up in JSON, logs or
+// WebSocket broadcasts, use Reveal to get the real value
+type Secret string
+
+// Reveal returns the real value of the secret
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// Value implements driver.Valuer so the database gets the real value, pgx would
+// otherwise encode a Secret through String
+func (s Secret) Value() (driver.Value, error) {
+	return string(s), nil
+}
+
+// String implements fmt.Stringer
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return Redacted
+}
+
+// GoString implements fmt.GoStringer so %#v is redacted too
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// MarshalJSON implements json.Marshaler
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// SerializableSensitiveFields returns the fields of a model that are tagged as
+// sensitive but would still be emitted by encoding/json. A sensitive field must
+// have the `json:"-"` tag and be of type Secret.
+func SerializableSensitiveFields(model any) []string {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			fields = append(fields, SerializableSensitiveFields(reflect.New(field.Type).Interface())...)
+			continue
+		}
+		if field.Tag.Get(TagSensitive) != "true" {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name != "-" || field.Type != reflect.TypeOf(Secret("")) {
+			fields = append(fields, t.Name()+"."+field.Name)
+		}
+	}
+	return fields
+}
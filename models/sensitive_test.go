@@ -0,0 +1,147 @@
+package models
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// allModels lists every struct declared in this package, TestAllModelsListed keeps it complete
+var allModels = []any{
+	Category{},
+	Event{},
+	ExchangeRate{},
+	IdempotencyKey{},
+	OutboxEvent{},
+	ProductImageThumbnail{},
+	ProductImage{},
+	ProductOption{},
+	Product{},
+	PurchaseOrder{},
+	PurchaseOrderItem{},
+	RefreshToken{},
+	RevokedToken{},
+	Sequence{},
+	StockMovement{},
+	Tag{},
+	User{},
+	WebhookSubscription{},
+	WebhookDelivery{},
+}
+
+func TestSensitiveFieldsAreNotSerializable(t *testing.T) {
+	for _, model := range allModels {
+		if fields := SerializableSensitiveFields(model); len(fields) > 0 {
+			t.Errorf("%T has serializable sensitive fields: %v", model, fields)
+		}
+	}
+}
+
+func TestSerializableSensitiveFieldsReportsLeaks(t *testing.T) {
+	type leaky struct {
+		Name     string `json:"name"`
+		Password string `json:"password" sensitive:"true"`
+		Token    Secret `json:"token" sensitive:"true"`
+		Hash     string `json:"-" sensitive:"true"`
+		Safe     Secret `json:"-" sensitive:"true"`
+	}
+
+	fields := SerializableSensitiveFields(&leaky{})
+	want := []string{"leaky.Password", "leaky.Token", "leaky.Hash"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("SerializableSensitiveFields() = %v, want %v", fields, want)
+	}
+}
+
+func TestAllModelsListed(t *testing.T) {
+	listed := make(map[string]bool, len(allModels))
+	for _, model := range allModels {
+		listed[reflect.TypeOf(model).Name()] = true
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", nil, 0)
+	if err != nil {
+		t.Fatalf("parse models: %v", err)
+	}
+	for _, file := range pkgs["models"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if _, isStruct := typeSpec.Type.(*ast.StructType); isStruct && !listed[typeSpec.Name.Name] {
+					t.Errorf("model %s is missing from allModels", typeSpec.Name.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestSecretEncodesRealValue(t *testing.T) {
+	m := pgtype.NewMap()
+	for _, oid := range []uint32{pgtype.TextOID, pgtype.VarcharOID} {
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			buf, err := m.Encode(oid, format, Secret("hash"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(buf) != "hash" {
+				t.Errorf("oid %d format %d: pgx encoded %q, want the real value", oid, format, buf)
+			}
+		}
+	}
+}
+
+// TestSecretDatabaseRoundTrip needs a Postgres database, set TEST_DATABASE_DSN to run it
+func TestSecretDatabaseRoundTrip(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&RefreshToken{}); err != nil {
+		t.Fatal(err)
+	}
+
+	hash := Secret(uuid.NewString())
+	token := RefreshToken{ID: uuid.New(), UserID: uuid.New(), TokenHash: hash, AccessTokenID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}
+	if err := db.Create(&token).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Delete(&token)
+	})
+
+	var stored string
+	if err := db.Raw("SELECT token_hash FROM refresh_tokens WHERE id = ?", token.ID).Scan(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored != hash.Reveal() {
+		t.Errorf("stored %q, want the real value", stored)
+	}
+
+	// a Secret used as a query argument matches the stored value and scans back unredacted
+	var loaded RefreshToken
+	if err := db.Where("token_hash = ?", hash).First(&loaded).Error; err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ID != token.ID || loaded.TokenHash.Reveal() != hash.Reveal() {
+		t.Errorf("loaded %s with hash %q, want %s with %q", loaded.ID, loaded.TokenHash.Reveal(), token.ID, hash.Reveal())
+	}
+}
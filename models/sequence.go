@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"gorm.io/gorm"
+)
+
+// Sequence is a named counter, Period is the year for counters that reset yearly and empty otherwise
+type Sequence struct {
+	Name      string    `json:"name" gorm:"type:varchar(64);primaryKey"`
+	Period    string    `json:"period" gorm:"type:varchar(16);primaryKey"`
+	Value     int64     `json:"value" gorm:"not null"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func NewSequence(db *gorm.DB) database.Repository[Sequence] {
+	return database.NewPostgresRepository[Sequence](db)
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reasons recorded on a stock movement. Every option's ledger starts with an opening balance.
+const (
+	StockReasonOpeningBalance   = "OPENING_BALANCE"
+	StockReasonManualAdjustment = "MANUAL_ADJUSTMENT"
+	StockReasonPOReservation    = "PO_RESERVATION"
+	StockReasonPOFulfilment     = "PO_FULFILMENT"
+	StockReasonReturn           = "RETURN"
+)
+
+// StockMovement is one ledger entry of a ProductOption stock change.
+// Summing Delta and ReservedDelta over all entries of an option gives its Quantity and Reserved.
+type StockMovement struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ProductOptionID uuid.UUID  `json:"product_option_id" gorm:"type:uuid;not null;index"`
+	Delta           int        `json:"delta" gorm:"not null;default:0"`
+	ReservedDelta   int        `json:"reserved_delta" gorm:"not null;default:0"`
+	Reason          string     `json:"reason" gorm:"type:varchar(50);not null;index"`
+	SourceID        *uuid.UUID `json:"source_id,omitempty" gorm:"type:uuid;index"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	CreatedBy       uuid.UUID  `json:"created_by" gorm:"type:uuid;"`
+}
+
+func NewStockMovement(db *gorm.DB) database.Repository[StockMovement] {
+	return database.NewPostgresRepository[StockMovement](db)
+}
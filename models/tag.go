@@ -0,0 +1,26 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tag is a free-form product label, names are stored normalized with NormalizeTag
+type Tag struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null;uniqueIndex"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// NormalizeTag trims and lowercases a tag name so the same tag is not stored twice
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func NewTag(db *gorm.DB) database.Repository[Tag] {
+	return database.NewPostgresRepository[Tag](db)
+}
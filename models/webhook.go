@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LeHNam/wao-api/services/database"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscription sends the listed event types to a partner URL, payloads are signed with Secret
+type WebhookSubscription struct {
+	ID         uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	URL        string         `json:"url" gorm:"type:text;not null"`
+	Secret     Secret         `json:"-" sensitive:"true" gorm:"type:varchar(128);not null"`
+	EventTypes datatypes.JSON `json:"event_types" gorm:"type:jsonb;not null"`
+	Active     bool           `json:"active" gorm:"not null;default:true"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedBy  uuid.UUID      `json:"created_by" gorm:"type:uuid;"`
+	UpdatedBy  uuid.UUID      `json:"updated_by" gorm:"type:uuid;"`
+	DeletedAt  *time.Time     `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+func NewWebhookSubscription(db *gorm.DB) database.Repository[WebhookSubscription] {
+	return database.NewPostgresRepository[WebhookSubscription](db)
+}
+
+const (
+	WebhookDeliveryPending   = "PENDING"
+	WebhookDeliverySucceeded = "SUCCEEDED"
+	WebhookDeliveryFailed    = "FAILED"
+)
+
+// WebhookDelivery is one event sent to one subscription, together with the outcome of the last attempt.
+// An event is queued once per subscription, EventID is also sent to the receiver as the dedupe key.
+type WebhookDelivery struct {
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	SubscriptionID uuid.UUID      `json:"subscription_id" gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_event"`
+	EventID        uuid.UUID      `json:"event_id" gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_event"`
+	EventType      string         `json:"event_type" gorm:"type:varchar(64);not null"`
+	Payload        datatypes.JSON `json:"payload" gorm:"type:jsonb;not null"`
+	Status         string         `json:"status" gorm:"type:varchar(16);not null;index"`
+	Attempts       int            `json:"attempts" gorm:"not null;default:0"`
+	ResponseCode   *int           `json:"response_code,omitempty"`
+	ResponseBody   string         `json:"response_body,omitempty" gorm:"type:text"`
+	LastError      string         `json:"last_error,omitempty" gorm:"type:text"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at" gorm:"not null;index"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func NewWebhookDelivery(db *gorm.DB) database.Repository[WebhookDelivery] {
+	return database.NewPostgresRepository[WebhookDelivery](db)
+}
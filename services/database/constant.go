@@ -6,7 +6,9 @@ const (
 	CONDITION_EXIST_IN_ARRAY_OF_OBJECT = " @>"
 	CONDITION_BETWEEN_AND              = " BETWEEN_AND"
 	CONDITION_LIKE                     = " LIKE"
+	CONDITION_PREFIX                   = " PREFIX"
 	CONDITION_NOT_NULL                 = " IS NOT NULL"
+	CONDITION_NULL                     = " IS NULL"
 	CONDITION_NOT_LIKE                 = " NOT_LIKE"
 	CONDITION_EQUAL                    = " EQUAL"
 	CONDITION_NOT_EQUAL                = " NOT_EQUAL"
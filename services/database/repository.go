@@ -124,6 +124,26 @@ func (r *PostgresRepository[T]) Find(ctx context.Context, conditions map[string]
 	return entities, nil
 }
 
+// FindForUpdate finds entities and locks their rows until the surrounding transaction ends.
+// Rows are locked in primary key order so concurrent callers cannot deadlock each other.
+func (r *PostgresRepository[T]) FindForUpdate(ctx context.Context, conditions map[string]interface{}) ([]T, error) {
+	var entities []T
+	query := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"})
+
+	query = applyConditions(query, conditions)
+
+	if exists := CheckIfColumnExists(new(T), "deleted_at"); exists {
+		query = query.Where("deleted_at IS NULL")
+	}
+
+	err := query.Order("id").Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
+
 func (r *PostgresRepository[T]) FindOne(ctx context.Context, conditions map[string]interface{}, selectFields []string) (*T, error) {
 	var entity T
 	query := r.db.WithContext(ctx)
@@ -164,6 +184,16 @@ func (r *PostgresRepository[T]) WithTx(tx *gorm.DB) Repository[T] {
 	return &PostgresRepository[T]{db: tx}
 }
 
+// ApplyConditions adds the conditions to a query built outside of the repository, in the same format as Find
+func ApplyConditions(query *gorm.DB, conditions map[string]interface{}) *gorm.DB {
+	return applyConditions(query, conditions)
+}
+
+// EscapeLike escapes the LIKE wildcards of a text so it matches literally, the pattern needs ESCAPE '\'
+func EscapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
+
 func applyConditions(query *gorm.DB, conditions map[string]interface{}) *gorm.DB {
 	if len(conditions) > 0 {
 		for key, value := range conditions {
@@ -192,8 +222,14 @@ func applyConditions(query *gorm.DB, conditions map[string]interface{}) *gorm.DB
 			case strings.HasSuffix(cleanedKey, CONDITION_LIKE):
 				cleanKey := strings.TrimSuffix(cleanedKey, CONDITION_LIKE)
 				query = query.Where("LOWER("+cleanKey+") LIKE ?", "%"+strings.ToLower(value.(string))+"%")
+			case strings.HasSuffix(cleanedKey, CONDITION_PREFIX):
+				// case sensitive so a text_pattern_ops index on the column can serve it
+				cleanKey := strings.TrimSuffix(cleanedKey, CONDITION_PREFIX)
+				query = query.Where(cleanKey+` LIKE ? ESCAPE '\'`, EscapeLike(value.(string))+"%")
 			case strings.HasSuffix(cleanedKey, CONDITION_NOT_NULL):
 				query = query.Where(cleanedKey)
+			case strings.HasSuffix(cleanedKey, CONDITION_NULL):
+				query = query.Where(cleanedKey)
 			case strings.HasSuffix(cleanedKey, CONDITION_NOT_LIKE):
 				cleanKey := strings.TrimSuffix(cleanedKey, CONDITION_NOT_LIKE)
 				query = query.Where("LOWER("+cleanKey+") NOT LIKE ?", "%"+strings.ToLower(value.(string))+"%")
@@ -260,6 +296,29 @@ func (r *PostgresRepository[T]) First(ctx context.Context, id uuid.UUID) (*T, er
 	return &entity, nil
 }
 
+// FirstForUpdate loads an entity by ID and locks its row until the surrounding transaction ends
+func (r *PostgresRepository[T]) FirstForUpdate(ctx context.Context, id uuid.UUID) (*T, error) {
+	var entity T
+
+	conditions := map[string]interface{}{
+		"id": id,
+	}
+
+	if exists := CheckIfColumnExists(new(T), "deleted_at"); exists {
+		conditions["deleted_at"] = nil
+	}
+
+	query := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"})
+	query = applyConditions(query, conditions)
+
+	err := query.First(&entity).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
 func (r *PostgresRepository[T]) FirstWithPreload(ctx context.Context, preloads []PreloadData, id uuid.UUID) (*T, error) {
 	var entity T
 
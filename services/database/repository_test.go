@@ -0,0 +1,41 @@
+package database
+
+import (
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"/a1b2/":    "/a1b2/",
+		"50%_off":   `50\%\_off`,
+		`back\path`: `back\\path`,
+	}
+	for text, want := range tests {
+		if got := EscapeLike(text); got != want {
+			t.Errorf("EscapeLike(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestPrefixCondition(t *testing.T) {
+	// without the ping nothing connects, a dry run only builds the statement
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []struct{ Path string }
+	stmt := applyConditions(db.Table("categories"), map[string]interface{}{
+		"path" + CONDITION_PREFIX: "/50%_off/",
+	}).Find(&rows).Statement
+
+	if want := `SELECT * FROM "categories" WHERE path LIKE $1 ESCAPE '\'`; stmt.SQL.String() != want {
+		t.Errorf("SQL = %s, want %s", stmt.SQL.String(), want)
+	}
+	if len(stmt.Vars) != 1 || stmt.Vars[0] != `/50\%\_off/%` {
+		t.Errorf("vars = %v, want the escaped prefix followed by %%", stmt.Vars)
+	}
+}
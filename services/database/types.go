@@ -31,10 +31,12 @@ type Repository[T any] interface {
 	WithTx(tx *gorm.DB) Repository[T]
 	Transaction(ctx context.Context, fn func(Repository[T]) error) error
 	Find(ctx context.Context, conditions map[string]interface{}, selectFields []string, limit, offset int, sort *string) ([]T, error)
+	FindForUpdate(ctx context.Context, conditions map[string]interface{}) ([]T, error)
 	Count(ctx context.Context, conditions map[string]interface{}) (int64, error)
 	Create(ctx context.Context, entity *T) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	First(ctx context.Context, id uuid.UUID) (*T, error)
+	FirstForUpdate(ctx context.Context, id uuid.UUID) (*T, error)
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	DeleteWhere(ctx context.Context, conditions map[string]interface{}) error
 	CreateMany(ctx context.Context, entities []T) error
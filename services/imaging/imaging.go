@@ -0,0 +1,120 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	// animated GIFs are stored as uploaded and thumbnailed from their first frame
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// maxPixels guards against small files that decode into huge images
+	maxPixels   = 50_000_000
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrTooManyPixels     = errors.New("image dimensions are too large")
+)
+
+// formats maps the sniffed content type to the file extension
+var formats = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Image is a decoded upload
+type Image struct {
+	ContentType string
+	Ext         string
+	Width       int
+	Height      int
+	img         image.Image
+}
+
+// Thumbnail is an encoded downscaled copy of an image
+type Thumbnail struct {
+	Size        int
+	Width       int
+	Height      int
+	ContentType string
+	Ext         string
+	Data        []byte
+}
+
+// Decode detects the format from the content, the name or content type sent by the client is not trusted
+func Decode(data []byte) (*Image, error) {
+	contentType := http.DetectContentType(data)
+	ext, ok := formats[contentType]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	return &Image{
+		ContentType: contentType,
+		Ext:         ext,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		img:         img,
+	}, nil
+}
+
+// Thumbnail scales the image to fit in a size x size box keeping its aspect ratio, images are never
+// enlarged. JPEG sources give JPEG thumbnails, the other formats PNG so transparency is kept.
+func (i *Image) Thumbnail(size int) (*Thumbnail, error) {
+	width, height := fit(i.Width, i.Height, size)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), i.img, i.img.Bounds(), draw.Src, nil)
+
+	thumb := &Thumbnail{
+		Size:   size,
+		Width:  width,
+		Height: height,
+	}
+	var buf bytes.Buffer
+	var err error
+	if i.ContentType == "image/jpeg" {
+		thumb.ContentType, thumb.Ext = "image/jpeg", ".jpg"
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		thumb.ContentType, thumb.Ext = "image/png", ".png"
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, err
+	}
+	thumb.Data = buf.Bytes()
+	return thumb, nil
+}
+
+func fit(width, height, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+	if width >= height {
+		return size, max(1, height*size/width)
+	}
+	return max(1, width*size/height), size
+}
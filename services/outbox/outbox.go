@@ -0,0 +1,191 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Sink receives the outbox events, it has to tolerate the same event (same ID) more than once
+type Sink interface {
+	Publish(ctx context.Context, event models.OutboxEvent) error
+}
+
+// SinkFunc adapts a function to a Sink
+type SinkFunc func(ctx context.Context, event models.OutboxEvent) error
+
+func (f SinkFunc) Publish(ctx context.Context, event models.OutboxEvent) error {
+	return f(ctx, event)
+}
+
+// NewEvent builds an outbox event for the given topics, owner is the user the event belongs to or uuid.Nil.
+// The event has to be created in the transaction of the change it describes.
+func NewEvent(eventType string, payload any, owner uuid.UUID, topics ...string) (*models.OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	topicData, err := json.Marshal(topics)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &models.OutboxEvent{
+		ID:            uuid.New(),
+		Type:          eventType,
+		Topics:        topicData,
+		Owner:         owner,
+		Payload:       data,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// Dispatcher polls the outbox table and delivers pending events to every sink.
+// Rows are claimed with FOR UPDATE SKIP LOCKED so several instances can run a dispatcher.
+type Dispatcher struct {
+	db  *gorm.DB
+	log *zap.Logger
+
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+
+	mu    sync.RWMutex
+	sinks map[string]Sink
+	wake  chan struct{}
+}
+
+func NewDispatcher(cfg *config.Config, db *gorm.DB, log *zap.Logger) *Dispatcher {
+	d := &Dispatcher{
+		db:           db,
+		log:          log,
+		pollInterval: cfg.Outbox.PollInterval,
+		batchSize:    cfg.Outbox.BatchSize,
+		maxAttempts:  cfg.Outbox.MaxAttempts,
+		sinks:        make(map[string]Sink),
+		wake:         make(chan struct{}, 1),
+	}
+	if d.pollInterval <= 0 {
+		d.pollInterval = time.Second
+	}
+	if d.batchSize <= 0 {
+		d.batchSize = 100
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = 10
+	}
+	return d
+}
+
+// AddSink registers a sink, sinks have to be added before Run
+func (d *Dispatcher) AddSink(name string, sink Sink) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sinks[name] = sink
+}
+
+// Notify wakes the dispatcher up, handlers call it after committing an outbox event
+func (d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run dispatches pending events until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := d.dispatchBatch(ctx)
+			if err != nil && ctx.Err() == nil {
+				d.log.Error("outbox dispatch failed", zap.Error(err))
+			}
+			// keep going while full batches are pending
+			if err != nil || n < d.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// dispatchBatch delivers one batch of due events and returns how many were claimed
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	tx := d.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+	defer tx.Rollback()
+
+	var events []models.OutboxEvent
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("published_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ?", time.Now()).
+		Order("created_at").
+		Limit(d.batchSize).
+		Find(&events).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		updates := map[string]any{}
+		if err := d.publish(ctx, event); err != nil {
+			attempts := event.Attempts + 1
+			updates["attempts"] = attempts
+			updates["last_error"] = err.Error()
+			updates["next_attempt_at"] = time.Now().Add(backoff(attempts))
+			if attempts >= d.maxAttempts {
+				updates["failed_at"] = time.Now()
+				d.log.Error("outbox event gave up", zap.String("id", event.ID.String()), zap.String("type", event.Type), zap.Error(err))
+			}
+		} else {
+			updates["published_at"] = time.Now()
+		}
+
+		err = tx.Model(&models.OutboxEvent{}).Where("id = ?", event.ID).Updates(updates).Error
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(events), tx.Commit().Error
+}
+
+// publish sends the event to every sink, a sink that already got it sees it again on retry
+func (d *Dispatcher) publish(ctx context.Context, event models.OutboxEvent) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for name, sink := range d.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			return fmt.Errorf("sink %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// backoff is exponential from 1s, capped at 10 minutes
+func backoff(attempts int) time.Duration {
+	delay := time.Second << min(attempts-1, 10)
+	return min(delay, 10*time.Minute)
+}
@@ -0,0 +1,77 @@
+package sequence
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+	"gorm.io/gorm"
+)
+
+// PurchaseOrder is the name of the purchase order number sequence
+const PurchaseOrder = "purchase_order"
+
+// Generator formats numbers from a counter stored in the sequences table, e.g. PO-2026-000042.
+// The counter is incremented with a single upsert, the row lock it takes serializes concurrent
+// callers on every instance, so two numbers are never the same.
+type Generator struct {
+	name        string
+	prefix      string
+	dateFormat  string
+	padding     int
+	resetYearly bool
+}
+
+// NewGenerator fails when the counter resets yearly but the date part has no year, numbers
+// would then repeat every year
+func NewGenerator(name string, cfg config.NumberFormat) (*Generator, error) {
+	if cfg.ResetYearly && !strings.Contains(cfg.DateFormat, "06") {
+		return nil, fmt.Errorf("%s number: reset_yearly needs a year in date_format, got %q", name, cfg.DateFormat)
+	}
+
+	g := &Generator{
+		name:        name,
+		prefix:      cfg.Prefix,
+		dateFormat:  cfg.DateFormat,
+		padding:     cfg.Padding,
+		resetYearly: cfg.ResetYearly,
+	}
+	if g.padding <= 0 {
+		g.padding = 6
+	}
+	return g, nil
+}
+
+// Next returns the next number. Called in the transaction that uses the number, the counter only
+// moves when it commits so numbers have no gaps, and concurrent callers wait for that commit.
+func (g *Generator) Next(ctx context.Context, tx *gorm.DB, now time.Time) (string, error) {
+	period := ""
+	if g.resetYearly {
+		period = strconv.Itoa(now.Year())
+	}
+
+	var value int64
+	err := tx.WithContext(ctx).Raw(`
+		INSERT INTO sequences (name, period, value, updated_at) VALUES (?, ?, 1, ?)
+		ON CONFLICT (name, period) DO UPDATE SET value = sequences.value + 1, updated_at = EXCLUDED.updated_at
+		RETURNING value`, g.name, period, now).Scan(&value).Error
+	if err != nil {
+		return "", fmt.Errorf("next %s number: %w", g.name, err)
+	}
+	return g.Format(value, now), nil
+}
+
+// Format renders a counter value: prefix, date part when configured, then the zero padded counter
+func (g *Generator) Format(value int64, now time.Time) string {
+	var number strings.Builder
+	number.WriteString(g.prefix)
+	if g.dateFormat != "" {
+		number.WriteString(now.Format(g.dateFormat))
+		number.WriteString("-")
+	}
+	number.WriteString(fmt.Sprintf("%0*d", g.padding, value))
+	return number.String()
+}
@@ -0,0 +1,106 @@
+package sequence
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestNewGeneratorRejectsYearlyResetWithoutYear(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.NumberFormat
+		wantErr bool
+	}{
+		{"yearly with year", config.NumberFormat{DateFormat: "2006", ResetYearly: true}, false},
+		{"yearly with short year", config.NumberFormat{DateFormat: "0601", ResetYearly: true}, false},
+		{"yearly without date", config.NumberFormat{ResetYearly: true}, true},
+		{"yearly with month only", config.NumberFormat{DateFormat: "01", ResetYearly: true}, true},
+		{"never reset without date", config.NumberFormat{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator(PurchaseOrder, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	g, err := NewGenerator(PurchaseOrder, config.NumberFormat{Prefix: "PO-", DateFormat: "2006", Padding: 6, ResetYearly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if got := g.Format(42, now); got != "PO-2026-000042" {
+		t.Errorf("Format() = %q, want PO-2026-000042", got)
+	}
+}
+
+// TestNextConcurrent needs a Postgres database, set TEST_DATABASE_DSN to run it
+func TestNextConcurrent(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.Sequence{}); err != nil {
+		t.Fatal(err)
+	}
+
+	name := "test_" + uuid.NewString()[:8]
+	t.Cleanup(func() {
+		db.Where("name = ?", name).Delete(&models.Sequence{})
+	})
+	g, err := NewGenerator(name, config.NumberFormat{Prefix: "T-", Padding: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers = 50
+	numbers := make([]string, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = db.Transaction(func(tx *gorm.DB) error {
+				number, err := g.Next(context.Background(), tx, time.Now())
+				numbers[i] = number
+				return err
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, workers)
+	for i, number := range numbers {
+		if errs[i] != nil {
+			t.Fatalf("Next() error = %v", errs[i])
+		}
+		if seen[number] {
+			t.Errorf("number %s was generated twice", number)
+		}
+		seen[number] = true
+	}
+	for value := int64(1); value <= workers; value++ {
+		if number := g.Format(value, time.Now()); !seen[number] {
+			t.Errorf("number %s is missing, the sequence has a gap", number)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/LeHNam/wao-api/services/storage"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// serveMedia serves stored files. Keys contain the id of the record a file belongs to and are never
+// reused, so the files are cached as immutable.
+func (s *Server) serveMedia(c *gin.Context) {
+	key, err := storage.CleanKey(c.Param("key"))
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", int(s.sc.Config.ProductImage.CacheMaxAge.Seconds())))
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	object, err := s.sc.Storage.Open(c.Request.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		c.Header("Cache-Control", "no-store")
+		c.Status(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		s.sc.Log.Error("failed to open stored file", zap.String("key", key), zap.Error(err))
+		c.Header("Cache-Control", "no-store")
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	defer object.Close()
+
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Last-Modified", object.ModTime.UTC().Format(http.TimeFormat))
+	c.DataFromReader(http.StatusOK, object.Size, object.ContentType, object, nil)
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/LeHNam/wao-api/models"
+	"gorm.io/gorm"
+)
+
+// moneyTables are the tables with money columns rounded to the minor units of their currency column
+var moneyTables = map[string][]string{
+	"purchase_orders":      {"total_amount"},
+	"purchase_order_items": {"unit_price", "total_price"},
+}
+
+// baseMoneyTables are the tables with money columns in the base currency, they have no currency column
+var baseMoneyTables = map[string][]string{
+	"product_options": {"price"},
+}
+
+// floatMoneyTables returns the tables whose money columns are still stored as floating point,
+// it has to be checked before AutoMigrate converts the columns to numeric
+func floatMoneyTables(db *gorm.DB) map[string]bool {
+	tables := make(map[string]bool)
+	for _, group := range []map[string][]string{moneyTables, baseMoneyTables} {
+		for table, columns := range group {
+			if hasFloatColumn(db, table, columns[0]) {
+				tables[table] = true
+			}
+		}
+	}
+	return tables
+}
+
+// hasFloatColumn reports whether a column exists and is not numeric
+func hasFloatColumn(db *gorm.DB, table, column string) bool {
+	if !db.Migrator().HasTable(table) {
+		return false
+	}
+	columnTypes, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		return false
+	}
+	for _, columnType := range columnTypes {
+		if columnType.Name() == column {
+			return !strings.EqualFold(columnType.DatabaseTypeName(), "numeric")
+		}
+	}
+	return false
+}
+
+// migrateMoney completes the numeric migration of the money columns.
+// Orders created before exchange rates were priced in the base currency, they get a rate of 1.
+// The amounts of the tables that were converted from floating point are rounded to their currency.
+// Soft deleted orders are migrated as well.
+func migrateMoney(db *gorm.DB, baseCurrency string, floatTables map[string]bool) error {
+	baseCurrency = strings.ToUpper(strings.TrimSpace(baseCurrency))
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		legacyOrders := tx.Unscoped().Model(&models.PurchaseOrder{}).Select("id").Where("base_currency IS NULL OR base_currency = ''")
+		err := tx.Unscoped().Model(&models.PurchaseOrderItem{}).
+			Where("purchase_order_id IN (?)", legacyOrders).
+			UpdateColumns(map[string]interface{}{"currency": baseCurrency, "exchange_rate": 1}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Unscoped().Model(&models.PurchaseOrder{}).
+			Where("base_currency IS NULL OR base_currency = ''").
+			UpdateColumns(map[string]interface{}{
+				"currency":          baseCurrency,
+				"base_currency":     baseCurrency,
+				"base_total_amount": gorm.Expr("total_amount"),
+				"exchange_rate":     1,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		for table, columns := range moneyTables {
+			if !floatTables[table] {
+				continue
+			}
+			var currencies []string
+			if err := tx.Table(table).Distinct("currency").Pluck("currency", &currencies).Error; err != nil {
+				return err
+			}
+			for _, currency := range currencies {
+				units := models.MinorUnits(currency)
+				updates := make(map[string]interface{}, len(columns))
+				for _, column := range columns {
+					updates[column] = gorm.Expr("round("+column+", ?)", units)
+				}
+				if err := tx.Table(table).Where("currency = ?", currency).UpdateColumns(updates).Error; err != nil {
+					return err
+				}
+			}
+		}
+		baseUnits := models.MinorUnits(baseCurrency)
+		for table, columns := range baseMoneyTables {
+			if !floatTables[table] {
+				continue
+			}
+			updates := make(map[string]interface{}, len(columns))
+			for _, column := range columns {
+				updates[column] = gorm.Expr("round("+column+", ?)", baseUnits)
+			}
+			if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Table(table).UpdateColumns(updates).Error; err != nil {
+				return err
+			}
+		}
+		if !floatTables["purchase_orders"] {
+			return nil
+		}
+		return tx.Unscoped().Model(&models.PurchaseOrder{}).
+			Where("base_total_amount IS NOT NULL").
+			UpdateColumn("base_total_amount", gorm.Expr("round(base_total_amount, ?)", baseUnits)).Error
+	})
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"gorm.io/gorm"
+)
+
+// searchFunctions keep products.search_text up to date: the name and code of the product and the names and
+// codes of its active options, lowercased and without accents. search_vector is generated from it.
+var searchFunctions = []string{
+	`CREATE EXTENSION IF NOT EXISTS unaccent`,
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE OR REPLACE FUNCTION product_search_text(p_id uuid, p_name text, p_code text) RETURNS text
+	LANGUAGE sql STABLE AS $$
+		SELECT unaccent(lower(concat_ws(' ', p_name, p_code, (
+			SELECT string_agg(o.name || ' ' || o.code, ' ' ORDER BY o.created_at)
+			FROM product_options o
+			WHERE o.product_id = p_id AND o.archived_at IS NULL
+		))))
+	$$`,
+	`CREATE OR REPLACE FUNCTION products_search_trigger() RETURNS trigger
+	LANGUAGE plpgsql AS $$
+	BEGIN
+		NEW.search_text := product_search_text(NEW.id, NEW.name, NEW.code);
+		RETURN NEW;
+	END
+	$$`,
+	`CREATE OR REPLACE FUNCTION product_options_search_trigger() RETURNS trigger
+	LANGUAGE plpgsql AS $$
+	BEGIN
+		IF TG_OP <> 'INSERT' THEN
+			UPDATE products SET search_text = product_search_text(id, name, code) WHERE id = OLD.product_id;
+		END IF;
+		IF TG_OP <> 'DELETE' AND (TG_OP = 'INSERT' OR NEW.product_id <> OLD.product_id) THEN
+			UPDATE products SET search_text = product_search_text(id, name, code) WHERE id = NEW.product_id;
+		END IF;
+		RETURN NULL;
+	END
+	$$`,
+	`DROP TRIGGER IF EXISTS products_search ON products`,
+	`CREATE TRIGGER products_search BEFORE INSERT OR UPDATE OF name, code ON products
+	FOR EACH ROW EXECUTE FUNCTION products_search_trigger()`,
+	`DROP TRIGGER IF EXISTS product_options_search ON product_options`,
+	`CREATE TRIGGER product_options_search AFTER INSERT OR DELETE OR UPDATE OF name, code, archived_at, product_id ON product_options
+	FOR EACH ROW EXECUTE FUNCTION product_options_search_trigger()`,
+}
+
+var searchIndexes = []string{
+	`CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING gin (search_vector)`,
+	`CREATE INDEX IF NOT EXISTS idx_products_search_text_trgm ON products USING gin (search_text gin_trgm_ops)`,
+}
+
+// migrateSearch adds the columns, triggers and indexes behind the product search. The columns are
+// maintained by the database only and are not part of models.Product.
+func migrateSearch(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		backfill := !tx.Migrator().HasColumn("products", "search_text")
+
+		for _, statement := range searchFunctions {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		if backfill {
+			statements := []string{
+				`ALTER TABLE products ADD COLUMN search_text text NOT NULL DEFAULT ''`,
+				`ALTER TABLE products ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (to_tsvector('simple', search_text)) STORED`,
+				`UPDATE products SET search_text = product_search_text(id, name, code)`,
+			}
+			for _, statement := range statements {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, statement := range searchIndexes {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
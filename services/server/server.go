@@ -3,18 +3,25 @@ package server
 import (
 	"context"
 	"fmt"
+	"github.com/LeHNam/wao-api/api/category"
+	exchangeRate "github.com/LeHNam/wao-api/api/exchange_rate"
 	"github.com/LeHNam/wao-api/api/product"
 	purchaseOrder "github.com/LeHNam/wao-api/api/purchase_order"
 	"github.com/LeHNam/wao-api/api/user"
+	"github.com/LeHNam/wao-api/api/webhook"
 	"github.com/LeHNam/wao-api/config"
+	"github.com/LeHNam/wao-api/constant"
 	"github.com/LeHNam/wao-api/middlewares"
+	"github.com/LeHNam/wao-api/models"
 	"github.com/LeHNam/wao-api/services/i18nService"
+	"github.com/LeHNam/wao-api/services/outbox"
 	"github.com/LeHNam/wao-api/services/websocket"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -37,6 +44,8 @@ func NewServer(sc *svCtx.ServiceContext, wsService *websocket.WebSocketService)
 	router := gin.Default()
 	router.Use(middlewares.CORSMiddleware())
 
+	sc.Outbox.AddSink("websocket", outbox.SinkFunc(wsService.PublishOutbox))
+
 	return &Server{
 		router:    router,
 		sc:        sc,
@@ -45,14 +54,44 @@ func NewServer(sc *svCtx.ServiceContext, wsService *websocket.WebSocketService)
 }
 
 func (s *Server) AutoMigrate() {
+	floatTables := floatMoneyTables(s.sc.DB)
+
 	err := s.sc.DB.AutoMigrate(
-	//&models.PurchaseOrderItem{},
-	//&models.User{},
+		&models.PurchaseOrder{},
+		&models.PurchaseOrderItem{},
+		//&models.User{},
+		&models.Category{},
+		&models.Tag{},
+		&models.Product{},
+		&models.ProductOption{},
+		&models.ProductImage{},
+		&models.StockMovement{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.Event{},
+		&models.OutboxEvent{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.IdempotencyKey{},
+		&models.Sequence{},
+		&models.ExchangeRate{},
 	)
 	if err != nil {
 
 		log.Fatalf("error auto migrating models: %v", err)
 	}
+
+	if err := migrateMoney(s.sc.DB, s.sc.Config.Currency.Base, floatTables); err != nil {
+		log.Fatalf("error migrating money columns: %v", err)
+	}
+
+	if err := migrateStock(s.sc.DB); err != nil {
+		log.Fatalf("error migrating stock ledger: %v", err)
+	}
+
+	if err := migrateSearch(s.sc.DB); err != nil {
+		log.Fatalf("error migrating product search: %v", err)
+	}
 }
 
 func (s *Server) SetupRoutes() {
@@ -83,12 +122,17 @@ func (s *Server) SetupRoutes() {
 	}
 
 	// register api group with swagger validator
-	authMiddlewareFactory := middlewares.BearerAuthMiddleware()
+	authMiddlewareFactory := middlewares.BearerAuthMiddleware(s.sc)
+	rbacMiddlewareFactory := middlewares.RBACMiddleware()
 	apiPrefix := "/api/v1"
 	apiGroupV1 := s.router.Group(
 		apiPrefix,
 		oMiddleware.OapiRequestValidatorWithOptions(swagger, &oMiddleware.Options{
 			ErrorHandler: func(c *gin.Context, err string, statusCode int) {
+				// authentication and authorization failures are reported by the validator as bad requests
+				if authStatus, ok := c.Get(middlewares.AuthStatusKey); ok {
+					statusCode = authStatus.(int)
+				}
 				c.JSON(statusCode, gin.H{
 					"message": "Validation failed",
 					"error":   err,
@@ -98,12 +142,16 @@ func (s *Server) SetupRoutes() {
 			Options: openapi3filter.Options{
 				AuthenticationFunc: func(c context.Context, input *openapi3filter.AuthenticationInput) error {
 					fmt.Println("Authentication input:", input)
-					return authMiddlewareFactory(c, input)
+					if err := authMiddlewareFactory(c, input); err != nil {
+						return err
+					}
+					return rbacMiddlewareFactory(c, input)
 				},
 			},
 		}))
 
-	apiGroupV1.Use()
+	// replayed responses are stored in plain text, so only routes that never return tokens or secrets are idempotent
+	idempotentGroupV1 := apiGroupV1.Group("", middlewares.IdempotencyMiddleware(s.sc))
 	{
 		userServer := user.NewUserServer(s.sc)
 		userHandler := user.NewStrictHandler(userServer, nil)
@@ -111,15 +159,53 @@ func (s *Server) SetupRoutes() {
 
 		productServer := product.NewProductServer(s.sc, s.wsService)
 		productHandler := product.NewStrictHandler(productServer, nil)
-		product.RegisterHandlersWithOptions(apiGroupV1, productHandler, product.GinServerOptions{})
+		product.RegisterHandlersWithOptions(idempotentGroupV1, productHandler, product.GinServerOptions{})
+
+		categoryServer := category.NewCategoryServer(s.sc)
+		categoryHandler := category.NewStrictHandler(categoryServer, nil)
+		category.RegisterHandlersWithOptions(apiGroupV1, categoryHandler, category.GinServerOptions{})
 
 		purchaseOrderServer := purchaseOrder.NewPurchaseOrderServer(s.sc, s.wsService)
 		purchaseOrderHandler := purchaseOrder.NewStrictHandler(purchaseOrderServer, nil)
-		purchaseOrder.RegisterHandlersWithOptions(apiGroupV1, purchaseOrderHandler, purchaseOrder.GinServerOptions{})
+		purchaseOrder.RegisterHandlersWithOptions(idempotentGroupV1, purchaseOrderHandler, purchaseOrder.GinServerOptions{})
+
+		exchangeRateServer := exchangeRate.NewExchangeRateServer(s.sc)
+		exchangeRateHandler := exchangeRate.NewStrictHandler(exchangeRateServer, nil)
+		exchangeRate.RegisterHandlersWithOptions(apiGroupV1, exchangeRateHandler, exchangeRate.GinServerOptions{})
+
+		webhookServer := webhook.NewWebhookServer(s.sc)
+		webhookHandler := webhook.NewStrictHandler(webhookServer, nil)
+		webhook.RegisterHandlersWithOptions(apiGroupV1, webhookHandler, webhook.GinServerOptions{})
 	}
 
+	s.router.GET("/media/*key", s.serveMedia)
+
 	s.router.GET("/ws", func(c *gin.Context) {
-		s.wsService.HandleWebSocket(c.Writer, c.Request)
+		accessToken, err := middlewares.AuthenticateToken(c.Request.Context(), s.sc, websocket.TokenFromRequest(c.Request))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+			return
+		}
+		s.wsService.HandleWebSocket(c.Writer, c.Request, websocket.Identity{
+			UserID:    accessToken.User.ID,
+			Role:      accessToken.User.Role,
+			ExpiresAt: accessToken.ExpiresAt,
+		})
+	})
+
+	// WebSocket hub metrics, admin only
+	s.router.GET("/ws/metrics", func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		accessToken, err := middlewares.AuthenticateToken(c.Request.Context(), s.sc, token)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+			return
+		}
+		if accessToken.User.Role != constant.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+			return
+		}
+		c.JSON(http.StatusOK, s.wsService.Stats())
 	})
 
 }
@@ -137,6 +223,11 @@ func (s *Server) Run() error {
 
 	i18nService.NewI18nService()
 
+	if err := s.wsService.Start(s.sc.Context()); err != nil {
+		return err
+	}
+	s.sc.Start()
+
 	// graceful shutdown
 	go func() {
 		quit := make(chan os.Signal, 1)
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"github.com/LeHNam/wao-api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// openingBalances gives every option without an opening balance one, dated at the creation of the option.
+// It holds whatever stock the ledger does not explain, so options that existed before the ledger keep their
+// quantity when it is rebuilt. New options get their opening balance when they are created, so this only
+// backfills once.
+const openingBalances = `INSERT INTO stock_movements (id, product_option_id, delta, reserved_delta, reason, created_at, created_by)
+SELECT gen_random_uuid(), o.id,
+	COALESCE(o.quantity, 0) - COALESCE(SUM(m.delta), 0),
+	o.reserved - COALESCE(SUM(m.reserved_delta), 0),
+	?, COALESCE(o.created_at, now()), ?
+FROM product_options o
+LEFT JOIN stock_movements m ON m.product_option_id = o.id
+WHERE NOT EXISTS (
+	SELECT 1 FROM stock_movements opening WHERE opening.product_option_id = o.id AND opening.reason = ?
+)
+GROUP BY o.id`
+
+// migrateStock backfills the opening balances of the stock ledger
+func migrateStock(db *gorm.DB) error {
+	return db.Exec(openingBalances, models.StockReasonOpeningBalance, uuid.Nil, models.StockReasonOpeningBalance).Error
+}
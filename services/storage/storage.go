@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object is a stored file opened for reading, the caller closes it
+type Object struct {
+	io.ReadCloser
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// Storage keeps uploaded files. Keys are slash separated relative paths, objects are written once and
+// never modified, a new version of a file is stored under a new key.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Open(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the storage backend selected in the config
+func New(cfg *config.Config) (Storage, error) {
+	switch cfg.Storage.Driver {
+	case "", "local":
+		return NewLocal(cfg.Storage.Local.Dir)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", cfg.Storage.Driver)
+	}
+}
+
+// URL returns the public URL of a key
+func URL(cfg *config.Config, key string) string {
+	return strings.TrimSuffix(cfg.Storage.PublicURL, "/") + "/" + key
+}
+
+// CleanKey validates a key and returns it in canonical form
+func CleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(key, "/")
+	if key == "" || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
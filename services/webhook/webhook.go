@@ -0,0 +1,271 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderID        = "X-Webhook-Id"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxResponseBody is how much of the receiver response is kept in the delivery log
+const maxResponseBody = 2048
+
+// Payload is the body posted to the subscription URL
+type Payload struct {
+	// ID is the event ID, it is the same on every attempt so receivers can dedupe
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Sign returns the X-Webhook-Signature value: sha256=hex(HMAC-SHA256(secret, "<timestamp>.<body>"))
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher queues a delivery per matching subscription for each outbox event and sends them with retries
+type Dispatcher struct {
+	db     *gorm.DB
+	log    *zap.Logger
+	client *http.Client
+
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	wake         chan struct{}
+}
+
+func NewDispatcher(cfg *config.Config, db *gorm.DB, log *zap.Logger) *Dispatcher {
+	d := &Dispatcher{
+		db:           db,
+		log:          log,
+		client:       &http.Client{Timeout: cfg.Webhook.Timeout},
+		pollInterval: cfg.Webhook.PollInterval,
+		batchSize:    cfg.Webhook.BatchSize,
+		maxAttempts:  cfg.Webhook.MaxAttempts,
+		wake:         make(chan struct{}, 1),
+	}
+	if d.client.Timeout <= 0 {
+		d.client.Timeout = 10 * time.Second
+	}
+	if d.pollInterval <= 0 {
+		d.pollInterval = 2 * time.Second
+	}
+	if d.batchSize <= 0 {
+		d.batchSize = 20
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = 8
+	}
+	return d
+}
+
+// Enqueue is the outbox sink, it queues the event for every active subscription to its type
+func (d *Dispatcher) Enqueue(ctx context.Context, event models.OutboxEvent) error {
+	var subscriptions []models.WebhookSubscription
+	err := d.db.WithContext(ctx).
+		Where("active AND deleted_at IS NULL AND event_types @> ?", fmt.Sprintf("[%q]", event.Type)).
+		Find(&subscriptions).Error
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	body, err := json.Marshal(Payload{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt.UTC(),
+		Data:      json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        body,
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  time.Now(),
+		})
+	}
+
+	// the outbox can hand over the same event again, the unique (subscription_id, event_id) index dedupes it
+	err = d.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error
+	if err == nil {
+		d.Notify()
+	}
+	return err
+}
+
+// Notify wakes the dispatcher up
+func (d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends the due deliveries until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := d.sendBatch(ctx)
+			if err != nil && ctx.Err() == nil {
+				d.log.Error("webhook dispatch failed", zap.Error(err))
+			}
+			if err != nil || n < d.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// sendBatch claims due deliveries and sends them, returns how many were claimed
+func (d *Dispatcher) sendBatch(ctx context.Context) (int, error) {
+	deliveries, err := d.claim(ctx)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		var subscription models.WebhookSubscription
+		err := d.db.WithContext(ctx).First(&subscription, "id = ?", delivery.SubscriptionID).Error
+		if err != nil {
+			return 0, err
+		}
+		if err := d.db.WithContext(ctx).Model(&delivery).Updates(d.send(ctx, subscription, delivery)).Error; err != nil {
+			return 0, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// claim locks a batch of due deliveries by pushing their next attempt past the send timeout,
+// so other instances skip them while the requests are in flight
+func (d *Dispatcher) claim(ctx context.Context) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, time.Now()).
+			Order("next_attempt_at").
+			Limit(d.batchSize).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+		lease := time.Now().Add(2 * d.client.Timeout)
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", lease).Error
+	})
+	return deliveries, err
+}
+
+// send posts the delivery and returns the delivery log updates
+func (d *Dispatcher) send(ctx context.Context, subscription models.WebhookSubscription, delivery models.WebhookDelivery) map[string]any {
+	attempts := delivery.Attempts + 1
+	updates := map[string]any{"attempts": attempts}
+
+	if !subscription.Active || subscription.DeletedAt != nil {
+		updates["status"] = models.WebhookDeliveryFailed
+		updates["last_error"] = "subscription is no longer active"
+		return updates
+	}
+
+	code, body, err := d.post(ctx, subscription, delivery)
+	if code != 0 {
+		updates["response_code"] = code
+		updates["response_body"] = body
+	}
+	if err == nil && code >= 200 && code < 300 {
+		updates["status"] = models.WebhookDeliverySucceeded
+		updates["delivered_at"] = time.Now()
+		updates["last_error"] = ""
+		return updates
+	}
+
+	if err != nil {
+		updates["last_error"] = err.Error()
+	} else {
+		updates["last_error"] = fmt.Sprintf("receiver responded with status %d", code)
+	}
+	if attempts >= d.maxAttempts {
+		updates["status"] = models.WebhookDeliveryFailed
+	} else {
+		updates["next_attempt_at"] = time.Now().Add(Backoff(attempts))
+	}
+	return updates
+}
+
+func (d *Dispatcher) post(ctx context.Context, subscription models.WebhookSubscription, delivery models.WebhookDelivery) (int, string, error) {
+	timestamp := time.Now().Unix()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "wao-webhooks/1.0")
+	request.Header.Set(HeaderID, delivery.EventID.String())
+	request.Header.Set(HeaderEvent, delivery.EventType)
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(subscription.Secret.Reveal(), timestamp, delivery.Payload))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, "", err
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBody))
+	return response.StatusCode, string(body), nil
+}
+
+// Backoff is exponential from 30s, capped at 6 hours
+func Backoff(attempts int) time.Duration {
+	delay := 30 * time.Second << min(attempts-1, 10)
+	return min(delay, 6*time.Hour)
+}
@@ -0,0 +1,238 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/datatypes"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testSecret = "whsec_test"
+
+// receiver is an httptest webhook endpoint answering with the queued status codes, then 200
+type receiver struct {
+	*httptest.Server
+	codes    []int
+	calls    atomic.Int32
+	requests chan *http.Request
+	bodies   chan []byte
+}
+
+func newReceiver(t *testing.T, codes ...int) *receiver {
+	t.Helper()
+	r := &receiver{codes: codes, requests: make(chan *http.Request, 10), bodies: make(chan []byte, 10)}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.requests <- req
+		r.bodies <- body
+
+		call := int(r.calls.Add(1)) - 1
+		code := http.StatusOK
+		if call < len(r.codes) {
+			code = r.codes[call]
+		}
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte("status " + strconv.Itoa(code)))
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func newTestDispatcher(db *gorm.DB, maxAttempts int) *Dispatcher {
+	cfg := &config.Config{}
+	cfg.Webhook.MaxAttempts = maxAttempts
+	cfg.Webhook.Timeout = 5 * time.Second
+	return NewDispatcher(cfg, db, zap.NewNop())
+}
+
+func newDelivery(subscription models.WebhookSubscription) models.WebhookDelivery {
+	return models.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		EventID:        uuid.New(),
+		EventType:      "order_created",
+		Payload:        datatypes.JSON(`{"id":"1","type":"order_created"}`),
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  time.Now(),
+	}
+}
+
+func TestSign(t *testing.T) {
+	// sha256=hex(HMAC-SHA256("secret", "1700000000.{}"))
+	const want = "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163"
+	if got := Sign("secret", 1700000000, []byte("{}")); got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+}
+
+func TestSendSignsRequest(t *testing.T) {
+	r := newReceiver(t)
+	subscription := models.WebhookSubscription{ID: uuid.New(), URL: r.URL, Secret: testSecret, Active: true}
+	delivery := newDelivery(subscription)
+
+	before := time.Now().Unix()
+	updates := newTestDispatcher(nil, 3).send(context.Background(), subscription, delivery)
+
+	req, body := <-r.requests, <-r.bodies
+	timestamp, err := strconv.ParseInt(req.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid %s header: %v", HeaderTimestamp, err)
+	}
+	if timestamp < before || timestamp > time.Now().Unix() {
+		t.Errorf("timestamp %d is not the send time", timestamp)
+	}
+	if got, want := req.Header.Get(HeaderSignature), Sign(testSecret, timestamp, body); got != want {
+		t.Errorf("%s = %s, want %s", HeaderSignature, got, want)
+	}
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("body = %s, want %s", body, delivery.Payload)
+	}
+	if req.Header.Get(HeaderID) != delivery.EventID.String() || req.Header.Get(HeaderEvent) != delivery.EventType {
+		t.Errorf("event headers = %s %s", req.Header.Get(HeaderID), req.Header.Get(HeaderEvent))
+	}
+
+	if updates["status"] != models.WebhookDeliverySucceeded || updates["response_code"] != http.StatusOK || updates["attempts"] != 1 {
+		t.Errorf("updates = %v, want a succeeded first attempt", updates)
+	}
+}
+
+func TestSendRetriesServerErrors(t *testing.T) {
+	r := newReceiver(t, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusInternalServerError)
+	subscription := models.WebhookSubscription{ID: uuid.New(), URL: r.URL, Secret: testSecret, Active: true}
+	delivery := newDelivery(subscription)
+	d := newTestDispatcher(nil, 3)
+
+	// pending with a growing backoff until the attempts run out
+	for attempt := 1; attempt <= 3; attempt++ {
+		start := time.Now()
+		updates := d.send(context.Background(), subscription, delivery)
+		if updates["attempts"] != attempt {
+			t.Fatalf("attempt %d: attempts = %v", attempt, updates["attempts"])
+		}
+		if updates["response_code"] != r.codes[attempt-1] || updates["last_error"] == "" {
+			t.Errorf("attempt %d: updates = %v, want the receiver status logged", attempt, updates)
+		}
+
+		if attempt < 3 {
+			if _, ok := updates["status"]; ok {
+				t.Errorf("attempt %d: status = %v, want the delivery to stay pending", attempt, updates["status"])
+			}
+			next, _ := updates["next_attempt_at"].(time.Time)
+			if delay := next.Sub(start); delay < Backoff(attempt) || delay > Backoff(attempt)+time.Second {
+				t.Errorf("attempt %d: retried in %v, want %v", attempt, delay, Backoff(attempt))
+			}
+		} else if updates["status"] != models.WebhookDeliveryFailed {
+			t.Errorf("last attempt: status = %v, want %s", updates["status"], models.WebhookDeliveryFailed)
+		}
+		delivery.Attempts = attempt
+	}
+}
+
+func TestSendInactiveSubscription(t *testing.T) {
+	r := newReceiver(t)
+	subscription := models.WebhookSubscription{ID: uuid.New(), URL: r.URL, Secret: testSecret, Active: false}
+
+	updates := newTestDispatcher(nil, 3).send(context.Background(), subscription, newDelivery(subscription))
+	if updates["status"] != models.WebhookDeliveryFailed {
+		t.Errorf("status = %v, want %s", updates["status"], models.WebhookDeliveryFailed)
+	}
+	if r.calls.Load() != 0 {
+		t.Error("an inactive subscription was called")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{9, 128 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, 6 * time.Hour},
+		{50, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+// TestDeliveryLog needs a Postgres database, set TEST_DATABASE_DSN to run it
+func TestDeliveryLog(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.WebhookSubscription{}, &models.WebhookDelivery{}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newReceiver(t, http.StatusServiceUnavailable)
+	subscription := models.WebhookSubscription{ID: uuid.New(), URL: r.URL, Secret: testSecret, EventTypes: datatypes.JSON(`["order_created"]`), Active: true}
+	delivery := newDelivery(subscription)
+	if err := db.Create(&subscription).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&delivery).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Delete(&delivery)
+		db.Delete(&subscription)
+	})
+
+	d := newTestDispatcher(db, 3)
+	load := func() models.WebhookDelivery {
+		var row models.WebhookDelivery
+		if err := db.First(&row, "id = ?", delivery.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		return row
+	}
+
+	// the receiver fails: still pending, retried later
+	if _, err := d.sendBatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	row := load()
+	if row.Status != models.WebhookDeliveryPending || row.Attempts != 1 || row.ResponseCode == nil || *row.ResponseCode != http.StatusServiceUnavailable {
+		t.Fatalf("after a 503: %+v", row)
+	}
+	if !row.NextAttemptAt.After(time.Now()) {
+		t.Errorf("next attempt %v is not in the future", row.NextAttemptAt)
+	}
+
+	// the retry is due and succeeds
+	if err := db.Model(&row).Update("next_attempt_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.sendBatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	row = load()
+	if row.Status != models.WebhookDeliverySucceeded || row.Attempts != 2 || *row.ResponseCode != http.StatusOK || row.DeliveredAt == nil {
+		t.Errorf("after a 200: %+v", row)
+	}
+}
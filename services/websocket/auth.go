@@ -0,0 +1,74 @@
+package websocket
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/LeHNam/wao-api/constant"
+	"github.com/google/uuid"
+)
+
+// SubprotocolAccessToken lets browsers, which can not set headers on a WebSocket handshake,
+// send the access token as a subprotocol: Sec-WebSocket-Protocol: access_token, <token>
+const SubprotocolAccessToken = "access_token"
+
+// Identity is the authenticated user of a connection
+type Identity struct {
+	UserID    uuid.UUID
+	Role      constant.Role
+	ExpiresAt time.Time
+}
+
+// CanReceive applies the REST row-level rules to an event: events owned by a user
+// are only delivered to that user and to staff
+func (i Identity) CanReceive(event Event) bool {
+	if event.Owner == uuid.Nil || i.Role.IsStaff() {
+		return true
+	}
+	return event.Owner == i.UserID
+}
+
+// TokenFromRequest returns the access token of a handshake request, from the
+// Authorization header or from the access_token subprotocol
+func TokenFromRequest(r *http.Request) string {
+	if token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); token != "" {
+		return token
+	}
+
+	protocols := websocketProtocols(r)
+	for i, protocol := range protocols {
+		if protocol == SubprotocolAccessToken && i+1 < len(protocols) {
+			return protocols[i+1]
+		}
+	}
+	return ""
+}
+
+func websocketProtocols(r *http.Request) []string {
+	var protocols []string
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			if protocol = strings.TrimSpace(protocol); protocol != "" {
+				protocols = append(protocols, protocol)
+			}
+		}
+	}
+	return protocols
+}
+
+// checkOrigin allows the configured origins, * allows every origin
+func checkOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		for _, allowed := range allowedOrigins {
+			if allowed == "*" || strings.EqualFold(allowed, origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,193 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	BroadcasterMemory   = "memory"
+	BroadcasterPostgres = "postgres"
+)
+
+// Broadcaster carries events to the WebSocket clients of every API instance
+type Broadcaster interface {
+	// Publish sends the event to every instance, this one included
+	Publish(ctx context.Context, event Event) error
+	// Start delivers the events published by any instance until ctx is done
+	Start(ctx context.Context, deliver func(Event)) error
+}
+
+// memoryBroadcaster only reaches the clients connected to this process
+type memoryBroadcaster struct {
+	mu      sync.RWMutex
+	deliver func(Event)
+}
+
+func NewMemoryBroadcaster() Broadcaster {
+	return &memoryBroadcaster{}
+}
+
+func (b *memoryBroadcaster) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	deliver := b.deliver
+	b.mu.RUnlock()
+
+	if deliver != nil {
+		deliver(event)
+	}
+	return nil
+}
+
+func (b *memoryBroadcaster) Start(ctx context.Context, deliver func(Event)) error {
+	b.mu.Lock()
+	b.deliver = deliver
+	b.mu.Unlock()
+	return nil
+}
+
+// PostgresChannel is the LISTEN/NOTIFY channel events are published on
+const PostgresChannel = "wao_events"
+
+// maxNotifyPayload is the NOTIFY payload limit of PostgreSQL
+const maxNotifyPayload = 8000
+
+var ErrEventTooLarge = errors.New("event is too large for NOTIFY")
+
+// postgresBroadcaster publishes events with pg_notify and receives them on a dedicated LISTEN connection
+type postgresBroadcaster struct {
+	db  *gorm.DB
+	dsn string
+	log *zap.Logger
+
+	mu      sync.RWMutex
+	deliver func(Event)
+}
+
+// notification is the NOTIFY payload, it keeps the routing fields an Event does not serialize
+type notification struct {
+	Event
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Owner   uuid.UUID       `json:"owner"`
+}
+
+func NewPostgresBroadcaster(db *gorm.DB, dsn string, log *zap.Logger) Broadcaster {
+	return &postgresBroadcaster{
+		db:  db,
+		dsn: dsn,
+		log: log,
+	}
+}
+
+// encodeNotification serializes an event into a NOTIFY payload
+func encodeNotification(event Event) ([]byte, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(notification{
+		Event:   event,
+		Payload: payload,
+		Owner:   event.Owner,
+	})
+}
+
+// decodeNotification restores an event from a NOTIFY payload, the payload stays raw JSON
+func decodeNotification(data []byte) (Event, error) {
+	var message notification
+	if err := json.Unmarshal(data, &message); err != nil {
+		return Event{}, err
+	}
+	event := message.Event
+	event.Payload = message.Payload
+	event.Owner = message.Owner
+	return event, nil
+}
+
+func (b *postgresBroadcaster) Publish(ctx context.Context, event Event) error {
+	message, err := encodeNotification(event)
+	if err != nil {
+		return err
+	}
+
+	if len(message) > maxNotifyPayload {
+		// other instances miss this event, at least deliver it to the clients connected here
+		b.deliverLocal(event)
+		return fmt.Errorf("%w: %d bytes", ErrEventTooLarge, len(message))
+	}
+
+	return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", PostgresChannel, string(message)).Error
+}
+
+func (b *postgresBroadcaster) Start(ctx context.Context, deliver func(Event)) error {
+	b.mu.Lock()
+	b.deliver = deliver
+	b.mu.Unlock()
+
+	go b.listen(ctx)
+	return nil
+}
+
+// listen keeps a LISTEN connection open, reconnecting with backoff until ctx is done
+func (b *postgresBroadcaster) listen(ctx context.Context) {
+	backoff := time.Second
+	for {
+		err := b.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		b.log.Error("websocket broadcaster lost its LISTEN connection", zap.Error(err), zap.Duration("retry_in", backoff))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, 30*time.Second)
+	}
+}
+
+func (b *postgresBroadcaster) listenOnce(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{PostgresChannel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		event, err := decodeNotification([]byte(n.Payload))
+		if err != nil {
+			b.log.Error("invalid websocket event notification", zap.Error(err))
+			continue
+		}
+		b.deliverLocal(event)
+	}
+}
+
+func (b *postgresBroadcaster) deliverLocal(event Event) {
+	b.mu.RLock()
+	deliver := b.deliver
+	b.mu.RUnlock()
+
+	if deliver != nil {
+		deliver(event)
+	}
+}
@@ -0,0 +1,179 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMemoryBroadcaster(t *testing.T) {
+	b := NewMemoryBroadcaster()
+	event := NewEvent(EventProductCreated, nil, ProductTopic(uuid.New()))
+
+	// nothing is listening yet, the event is lost without an error
+	if err := b.Publish(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Event
+	if err := b.Start(context.Background(), func(e Event) { got = append(got, e) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Publish(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != event.ID {
+		t.Errorf("delivered %+v, want event %s once", got, event.ID)
+	}
+}
+
+func TestNotificationRoundTrip(t *testing.T) {
+	owner := uuid.New()
+	event := NewEvent(EventOrderUpdated, map[string]any{"status": "APPROVED", "total": "12.50"}, PurchaseOrderTopic(uuid.New())).WithOwner(owner)
+	event.Seq = 42
+
+	data, err := encodeNotification(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeNotification(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.ID != event.ID || got.Seq != event.Seq || got.Type != event.Type || got.Version != event.Version {
+		t.Errorf("decoded %+v, want %+v", got, event)
+	}
+	if !got.Timestamp.Equal(event.Timestamp) {
+		t.Errorf("timestamp = %v, want %v", got.Timestamp, event.Timestamp)
+	}
+	if strings.Join(got.Topics, ",") != strings.Join(event.Topics, ",") {
+		t.Errorf("topics = %v, want %v", got.Topics, event.Topics)
+	}
+	// the owner is not part of the client envelope but has to survive the trip for routing
+	if got.Owner != owner {
+		t.Errorf("owner = %s, want %s", got.Owner, owner)
+	}
+	payload, _ := json.Marshal(got.Payload)
+	if string(payload) != `{"status":"APPROVED","total":"12.50"}` {
+		t.Errorf("payload = %s", payload)
+	}
+}
+
+func TestDecodeNotificationInvalid(t *testing.T) {
+	if _, err := decodeNotification([]byte("not json")); err == nil {
+		t.Error("decodeNotification() accepted an invalid payload")
+	}
+}
+
+func TestPostgresBroadcasterEventTooLarge(t *testing.T) {
+	b := NewPostgresBroadcaster(nil, "", zap.NewNop())
+	var got []Event
+	b.(*postgresBroadcaster).deliver = func(e Event) { got = append(got, e) }
+
+	event := NewEvent(EventProductCreated, strings.Repeat("x", maxNotifyPayload), ProductTopic(uuid.New()))
+	err := b.Publish(context.Background(), event)
+	if !errors.Is(err, ErrEventTooLarge) {
+		t.Fatalf("Publish() error = %v, want %v", err, ErrEventTooLarge)
+	}
+	if len(got) != 1 || got[0].ID != event.ID {
+		t.Errorf("an oversized event should still reach the local clients, delivered %+v", got)
+	}
+}
+
+// TestPostgresBroadcasterCluster needs a Postgres database, set TEST_DATABASE_DSN to run it
+func TestPostgresBroadcasterCluster(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// two instances sharing the database
+	instances := make([]Broadcaster, 2)
+	received := make([]chan Event, 2)
+	for i := range instances {
+		instances[i] = NewPostgresBroadcaster(db, dsn, zap.NewNop())
+		received[i] = make(chan Event, 100)
+		ch := received[i]
+		if err := instances[i].Start(ctx, func(e Event) { ch <- e }); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// LISTEN is set up in the background, publish until both instances hear each other
+	for i := range instances {
+		deadline := time.Now().Add(10 * time.Second)
+		for !waitEvent(t, instances[i], received[1-i], deadline) {
+			// the other instance is not listening yet, waitEvent fails the test at the deadline
+		}
+	}
+
+	for i := range instances {
+		event := NewEvent(EventOrderUpdated, map[string]string{"from": "instance"}, PurchaseOrderTopic(uuid.New())).WithOwner(uuid.New())
+		if err := instances[i].Publish(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+		for j := range instances {
+			got := expectEvent(t, received[j], event.ID)
+			if got.Owner != event.Owner {
+				t.Errorf("instance %d got owner %s, want %s", j, got.Owner, event.Owner)
+			}
+		}
+	}
+}
+
+// waitEvent publishes a probe and reports whether it reached ch within a second
+func waitEvent(t *testing.T, b Broadcaster, ch chan Event, deadline time.Time) bool {
+	t.Helper()
+	if time.Now().After(deadline) {
+		t.Fatal("instances never received each other's events")
+	}
+	probe := NewEvent(EventProductCreated, nil, ProductTopic(uuid.New()))
+	if err := b.Publish(context.Background(), probe); err != nil {
+		t.Fatal(err)
+	}
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case e := <-ch:
+			if e.ID == probe.ID {
+				return true
+			}
+		case <-timeout:
+			return false
+		}
+	}
+}
+
+// expectEvent waits for the event with the given ID, skipping probes
+func expectEvent(t *testing.T, ch chan Event, id uuid.UUID) Event {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e := <-ch:
+			if e.ID == id {
+				return e
+			}
+		case <-timeout:
+			t.Fatalf("event %s was not delivered", id)
+		}
+	}
+}
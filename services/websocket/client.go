@@ -0,0 +1,272 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// maxMessageSize is the largest message a client can send
+const maxMessageSize = 4096
+
+// Client is a single WebSocket connection and the topics it is subscribed to.
+// Events are queued on send and written by the client's own writer goroutine,
+// so a slow connection never blocks the publisher.
+type Client struct {
+	ws       *WebSocketService
+	conn     *websocket.Conn
+	identity Identity
+
+	topics map[string]bool
+	mu     sync.Mutex
+
+	// while replaying, live events are held in pending so they are sent after the missed ones
+	replaying bool
+	pending   []Event
+
+	send      chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+	slow      atomic.Bool
+}
+
+func newClient(ws *WebSocketService, conn *websocket.Conn, identity Identity) *Client {
+	return &Client{
+		ws:       ws,
+		conn:     conn,
+		identity: identity,
+		topics:   make(map[string]bool),
+		send:     make(chan Event, ws.sendBuffer),
+		done:     make(chan struct{}),
+	}
+}
+
+// readPump reads client messages until the connection fails or is closed
+func (c *Client) readPump() {
+	defer c.close()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(c.ws.pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(c.ws.pongWait))
+	})
+
+	for {
+		var message ClientMessage
+		if err := c.conn.ReadJSON(&message); err != nil {
+			if !isJSONError(err) {
+				return
+			}
+			c.enqueue(NewEvent(EventError, ErrorPayload{Message: "invalid message"}))
+			continue
+		}
+		c.handleMessage(message)
+	}
+}
+
+// writePump is the only goroutine writing to the connection, it also sends the keepalive pings
+func (c *Client) writePump() {
+	ticker := time.NewTicker(c.ws.pingInterval)
+	defer func() {
+		ticker.Stop()
+		c.close()
+	}()
+
+	for {
+		select {
+		case event := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(c.ws.writeWait))
+			if err := c.conn.WriteJSON(event); err != nil {
+				return
+			}
+			c.ws.metrics.sent.Add(1)
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(c.ws.writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// enqueue queues an event without blocking, applying the slow consumer policy when the queue is full
+func (c *Client) enqueue(event Event) {
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	select {
+	case c.send <- event:
+	default:
+		c.ws.metrics.dropped.Add(1)
+		if c.ws.slowConsumer == SlowConsumerDisconnect && c.slow.CompareAndSwap(false, true) {
+			c.ws.metrics.disconnected.Add(1)
+			// the close frame write can block on a slow connection, keep the publisher out of it
+			go c.closeWith(websocket.CloseTryAgainLater, "slow consumer")
+		}
+	}
+}
+
+// enqueueReplay queues a replayed event, waiting for room instead of applying the slow consumer policy,
+// a resuming client is behind by design. It reports false once the client is closed.
+func (c *Client) enqueueReplay(event Event) bool {
+	timer := time.NewTimer(c.ws.writeWait)
+	defer timer.Stop()
+
+	select {
+	case c.send <- event:
+		return true
+	case <-c.done:
+		return false
+	case <-timer.C:
+		// the writer made no progress for a whole write timeout, the client is really stuck
+		c.enqueue(event)
+		return !c.slow.Load()
+	}
+}
+
+// closeWith sends a close frame with the given code before closing the connection
+func (c *Client) closeWith(code int, reason string) {
+	message := websocket.FormatCloseMessage(code, reason)
+	_ = c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(c.ws.writeWait))
+	c.close()
+}
+
+// close unregisters the client and closes the connection, it is safe to call more than once
+func (c *Client) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.ws.unregister(c)
+		_ = c.conn.Close()
+	})
+}
+
+func (c *Client) handleMessage(message ClientMessage) {
+	if len(message.Topics) == 0 {
+		c.enqueue(NewEvent(EventError, ErrorPayload{Message: "topics is required"}))
+		return
+	}
+	for _, topic := range message.Topics {
+		if err := ValidateTopic(topic); err != nil {
+			c.enqueue(NewEvent(EventError, ErrorPayload{Message: err.Error()}))
+			return
+		}
+	}
+
+	switch message.Action {
+	case ActionSubscribe:
+		c.mu.Lock()
+		for _, topic := range message.Topics {
+			c.topics[topic] = true
+		}
+		c.replaying = message.ResumeFrom != nil
+		c.mu.Unlock()
+		c.enqueue(NewEvent(EventSubscribed, SubscriptionPayload{Topics: message.Topics}))
+		if message.ResumeFrom != nil {
+			c.replay(*message.ResumeFrom)
+		}
+	case ActionUnsubscribe:
+		c.mu.Lock()
+		for _, topic := range message.Topics {
+			delete(c.topics, topic)
+		}
+		c.mu.Unlock()
+		c.enqueue(NewEvent(EventUnsubscribed, SubscriptionPayload{Topics: message.Topics}))
+	default:
+		c.enqueue(NewEvent(EventError, ErrorPayload{Message: "unknown action " + string(message.Action)}))
+	}
+}
+
+// deliver queues a live event, holding it back while missed events are being replayed
+func (c *Client) deliver(event Event) {
+	c.mu.Lock()
+	if c.replaying {
+		c.pending = append(c.pending, event)
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+	c.enqueue(event)
+}
+
+// replay sends the retained events after seq that match the subscriptions, then the live events held meanwhile.
+// It runs on the reader goroutine and waits for room in the send queue, so a backlog larger than the queue
+// is paced by the writer instead of disconnecting the client.
+func (c *Client) replay(seq int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.ws.writeWait)
+	defer cancel()
+
+	last := seq
+	events, ok, err := c.ws.store.Since(ctx, seq)
+	switch {
+	case err != nil:
+		c.enqueue(NewEvent(EventError, ErrorPayload{Message: "failed to load missed events"}))
+	case !ok:
+		latest, _ := c.ws.store.Latest(ctx)
+		c.enqueue(NewEvent(EventResyncRequired, ResyncPayload{LatestSeq: latest}))
+		// everything up to latest is covered by the resync
+		last = latest
+	default:
+		for _, event := range events {
+			if c.subscribed(event) && !c.enqueueReplay(event) {
+				return
+			}
+			last = event.Seq
+		}
+	}
+
+	// live events keep arriving while the held ones are sent, drain until none are left
+	for {
+		c.mu.Lock()
+		pending := c.pending
+		c.pending = nil
+		if len(pending) == 0 {
+			c.replaying = false
+			c.mu.Unlock()
+			return
+		}
+		c.mu.Unlock()
+
+		for _, event := range pending {
+			// skip live events that were part of the replay
+			if event.Seq > last && !c.enqueueReplay(event) {
+				return
+			}
+		}
+	}
+}
+
+// subscribed reports whether the client is subscribed to one of the event topics and allowed to see it
+func (c *Client) subscribed(event Event) bool {
+	if !c.identity.CanReceive(event) {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for subscription := range c.topics {
+		for _, topic := range event.Topics {
+			if matchTopic(subscription, topic, event.Owner, c.identity.UserID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isJSONError reports whether a read failed on a malformed message rather than on the connection
+func isJSONError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
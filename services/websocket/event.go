@@ -0,0 +1,90 @@
+package websocket
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProtocolVersion is the version of the event envelope, it is bumped on breaking changes
+const ProtocolVersion = 1
+
+type EventType string
+
+const (
+	EventProductCreated EventType = "product_created"
+	EventOrderCreated   EventType = "order_created"
+	EventOrderUpdated   EventType = "order_updated"
+
+	// control events sent to a single client
+	EventSubscribed   EventType = "subscribed"
+	EventUnsubscribed EventType = "unsubscribed"
+	EventError        EventType = "error"
+	// EventResyncRequired tells a resuming client that the events it missed are no longer retained,
+	// it has to reload its state over the REST API
+	EventResyncRequired EventType = "resync_required"
+)
+
+// Event is the envelope of every message the server sends over the WebSocket
+type Event struct {
+	Version int `json:"version"`
+	// Seq increases with every published event, control events have none
+	Seq       int64     `json:"seq,omitempty"`
+	ID        uuid.UUID `json:"id"`
+	Type      EventType `json:"type"`
+	Topics    []string  `json:"topics,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Payload   any       `json:"payload,omitempty"`
+
+	// Owner is the user the event belongs to, it routes the event to *:mine subscriptions
+	Owner uuid.UUID `json:"-"`
+}
+
+// NewEvent creates an event published to the given topics
+func NewEvent(eventType EventType, payload any, topics ...string) Event {
+	return Event{
+		Version:   ProtocolVersion,
+		ID:        uuid.New(),
+		Type:      eventType,
+		Topics:    topics,
+		Timestamp: time.Now().UTC(),
+		Payload:   payload,
+	}
+}
+
+// WithOwner sets the user the event belongs to
+func (e Event) WithOwner(owner uuid.UUID) Event {
+	e.Owner = owner
+	return e
+}
+
+type Action string
+
+const (
+	ActionSubscribe   Action = "subscribe"
+	ActionUnsubscribe Action = "unsubscribe"
+)
+
+// ClientMessage is a message sent by a client
+type ClientMessage struct {
+	Action Action   `json:"action"`
+	Topics []string `json:"topics"`
+	// ResumeFrom replays the retained events after this sequence number before live events, subscribe only
+	ResumeFrom *int64 `json:"resume_from,omitempty"`
+}
+
+// ErrorPayload is the payload of an error event
+type ErrorPayload struct {
+	Message string `json:"message"`
+}
+
+// SubscriptionPayload is the payload of subscribed and unsubscribed events
+type SubscriptionPayload struct {
+	Topics []string `json:"topics"`
+}
+
+// ResyncPayload is the payload of a resync_required event
+type ResyncPayload struct {
+	// LatestSeq is the sequence number to resume from once the client has reloaded its state
+	LatestSeq int64 `json:"latest_seq"`
+}
@@ -0,0 +1,184 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/LeHNam/wao-api/models"
+	"github.com/LeHNam/wao-api/services/database"
+	"gorm.io/gorm"
+)
+
+// ErrDuplicateEvent is returned by Append for an event ID that is already retained
+var ErrDuplicateEvent = errors.New("event has already been published")
+
+// EventStore numbers events and retains the most recent ones for replay
+type EventStore interface {
+	// Append assigns the next sequence number to the event and retains it,
+	// an event ID that is already retained gives ErrDuplicateEvent
+	Append(ctx context.Context, event Event) (Event, error)
+	// Since returns the retained events after seq in order.
+	// ok is false when some of those events are no longer retained, or seq is ahead of the store
+	// (e.g. the sequence restarted), and the client has to resync.
+	Since(ctx context.Context, seq int64) (events []Event, ok bool, err error)
+	// Latest returns the last assigned sequence number
+	Latest(ctx context.Context) (int64, error)
+}
+
+// ringStore keeps the last events of this process in a bounded ring buffer
+type ringStore struct {
+	mu     sync.Mutex
+	events []Event
+	next   int
+	seq    int64
+}
+
+func NewRingStore(capacity int) EventStore {
+	return &ringStore{events: make([]Event, capacity)}
+}
+
+func (s *ringStore) Append(ctx context.Context, event Event) (Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, retained := range s.events {
+		if retained.ID == event.ID && retained.Seq > 0 {
+			return event, ErrDuplicateEvent
+		}
+	}
+
+	s.seq++
+	event.Seq = s.seq
+	s.events[s.next] = event
+	s.next = (s.next + 1) % len(s.events)
+	return event, nil
+}
+
+func (s *ringStore) Since(ctx context.Context, seq int64) ([]Event, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if seq > s.seq {
+		// the client saw a sequence this process never reached, it restarted since
+		return nil, false, nil
+	}
+	if seq == s.seq {
+		return nil, true, nil
+	}
+	oldest := max(s.seq-int64(len(s.events))+1, 1)
+	if seq+1 < oldest {
+		return nil, false, nil
+	}
+
+	events := make([]Event, 0, s.seq-seq)
+	for n := seq + 1; n <= s.seq; n++ {
+		events = append(events, s.events[(n-1)%int64(len(s.events))])
+	}
+	return events, true, nil
+}
+
+func (s *ringStore) Latest(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seq, nil
+}
+
+// tableStore keeps events in the events table, so every instance shares the same sequence
+type tableStore struct {
+	db       *gorm.DB
+	capacity int64
+}
+
+func NewTableStore(db *gorm.DB, capacity int) EventStore {
+	return &tableStore{db: db, capacity: int64(capacity)}
+}
+
+func (s *tableStore) Append(ctx context.Context, event Event) (Event, error) {
+	topics, err := json.Marshal(event.Topics)
+	if err != nil {
+		return event, err
+	}
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return event, err
+	}
+
+	row := models.Event{
+		ID:        event.ID,
+		Type:      string(event.Type),
+		Topics:    topics,
+		Owner:     event.Owner,
+		Payload:   payload,
+		CreatedAt: event.Timestamp,
+	}
+	if err := s.db.WithContext(ctx).Create(&row).Error; err != nil {
+		if database.IsDuplicateKeyError(err) {
+			return event, ErrDuplicateEvent
+		}
+		return event, err
+	}
+	event.Seq = row.Seq
+
+	// keep the table bounded, pruning every 100 events is enough
+	if row.Seq%100 == 0 {
+		err = s.db.WithContext(ctx).Where("seq <= ?", row.Seq-s.capacity).Delete(&models.Event{}).Error
+	}
+	return event, err
+}
+
+func (s *tableStore) Since(ctx context.Context, seq int64) ([]Event, bool, error) {
+	latest, err := s.Latest(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if seq > latest {
+		return nil, false, nil
+	}
+	if seq == latest {
+		return nil, true, nil
+	}
+	if latest-seq > s.capacity {
+		return nil, false, nil
+	}
+
+	var oldest int64
+	err = s.db.WithContext(ctx).Model(&models.Event{}).Select("COALESCE(MIN(seq), 0)").Scan(&oldest).Error
+	if err != nil {
+		return nil, false, err
+	}
+	if oldest == 0 || seq+1 < oldest {
+		return nil, false, nil
+	}
+
+	var rows []models.Event
+	err = s.db.WithContext(ctx).Where("seq > ?", seq).Order("seq").Find(&rows).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	events := make([]Event, 0, len(rows))
+	for _, row := range rows {
+		event := Event{
+			Version:   ProtocolVersion,
+			Seq:       row.Seq,
+			ID:        row.ID,
+			Type:      EventType(row.Type),
+			Timestamp: row.CreatedAt.UTC(),
+			Payload:   json.RawMessage(row.Payload),
+			Owner:     row.Owner,
+		}
+		if err := json.Unmarshal(row.Topics, &event.Topics); err != nil {
+			return nil, false, err
+		}
+		events = append(events, event)
+	}
+	return events, true, nil
+}
+
+func (s *tableStore) Latest(ctx context.Context) (int64, error) {
+	var latest int64
+	err := s.db.WithContext(ctx).Model(&models.Event{}).Select("COALESCE(MAX(seq), 0)").Scan(&latest).Error
+	return latest, err
+}
@@ -0,0 +1,76 @@
+package websocket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Topics have the form <resource>:<id>. The id can also be * for every resource
+// of that kind, or mine for the resources owned by the connected user.
+const (
+	TopicProduct       = "product"
+	TopicPurchaseOrder = "purchase_order"
+
+	TopicWildcard = "*"
+	TopicMine     = "mine"
+)
+
+// topicResources lists the resources a client can subscribe to and whether they support mine
+var topicResources = map[string]bool{
+	TopicProduct:       false,
+	TopicPurchaseOrder: true,
+}
+
+func ProductTopic(id uuid.UUID) string {
+	return TopicProduct + ":" + id.String()
+}
+
+func PurchaseOrderTopic(id uuid.UUID) string {
+	return TopicPurchaseOrder + ":" + id.String()
+}
+
+// ValidateTopic checks that a client can subscribe to the topic
+func ValidateTopic(topic string) error {
+	resource, id, ok := strings.Cut(topic, ":")
+	if !ok || id == "" {
+		return fmt.Errorf("invalid topic %q, expected <resource>:<id>", topic)
+	}
+	supportsMine, known := topicResources[resource]
+	if !known {
+		return fmt.Errorf("unknown topic resource %q", resource)
+	}
+	switch id {
+	case TopicWildcard:
+		return nil
+	case TopicMine:
+		if !supportsMine {
+			return fmt.Errorf("topic %q does not support %s", resource, TopicMine)
+		}
+		return nil
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return fmt.Errorf("invalid id in topic %q", topic)
+	}
+	return nil
+}
+
+// matchTopic reports whether a subscription matches a topic an event is published to
+func matchTopic(subscription, topic string, owner, user uuid.UUID) bool {
+	if subscription == topic {
+		return true
+	}
+	subResource, subID, _ := strings.Cut(subscription, ":")
+	resource, _, _ := strings.Cut(topic, ":")
+	if subResource != resource {
+		return false
+	}
+	switch subID {
+	case TopicWildcard:
+		return true
+	case TopicMine:
+		return user != uuid.Nil && owner == user
+	}
+	return false
+}
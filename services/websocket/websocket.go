@@ -1,85 +1,209 @@
 package websocket
 
 import (
-	"fmt"
-	"github.com/gorilla/websocket"
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+	"github.com/LeHNam/wao-api/models"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SlowConsumerPolicy decides what happens to a client whose send queue is full
+type SlowConsumerPolicy string
+
+const (
+	// SlowConsumerDrop drops the event for that client and keeps the connection open
+	SlowConsumerDrop SlowConsumerPolicy = "drop"
+	// SlowConsumerDisconnect closes the connection, the client is expected to reconnect
+	SlowConsumerDisconnect SlowConsumerPolicy = "disconnect"
 )
 
 type WebSocketService struct {
 	upgrader    websocket.Upgrader
-	connections map[*websocket.Conn]bool
-	mu          sync.Mutex
+	clients     map[*Client]bool
+	mu          sync.RWMutex
+	broadcaster Broadcaster
+	store       EventStore
+
+	sendBuffer   int
+	slowConsumer SlowConsumerPolicy
+	pingInterval time.Duration
+	pongWait     time.Duration
+	writeWait    time.Duration
+
+	metrics metrics
+}
+
+type metrics struct {
+	sent         atomic.Int64
+	dropped      atomic.Int64
+	disconnected atomic.Int64
 }
 
-func NewWebSocketService() *WebSocketService {
-	return &WebSocketService{
+// Stats are the hub metrics
+type Stats struct {
+	ConnectedClients int   `json:"connected_clients"`
+	MessagesSent     int64 `json:"messages_sent"`
+	MessagesDropped  int64 `json:"messages_dropped"`
+	// SlowConsumersDisconnected counts clients closed by the disconnect policy
+	SlowConsumersDisconnected int64 `json:"slow_consumers_disconnected"`
+}
+
+func NewWebSocketService(cfg *config.Config, db *gorm.DB, log *zap.Logger) *WebSocketService {
+	ws := &WebSocketService{
 		upgrader: websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				// Allow all origins for simplicity; customize as needed
-				return true
-			},
+			CheckOrigin:  checkOrigin(cfg.WebSocket.AllowedOrigins),
+			Subprotocols: []string{SubprotocolAccessToken},
 		},
-		connections: make(map[*websocket.Conn]bool),
-		mu:          sync.Mutex{},
+		clients:      make(map[*Client]bool),
+		sendBuffer:   cfg.WebSocket.SendBuffer,
+		slowConsumer: SlowConsumerPolicy(cfg.WebSocket.SlowConsumerPolicy),
+		pingInterval: cfg.WebSocket.PingInterval,
+		pongWait:     cfg.WebSocket.PongWait,
+		writeWait:    cfg.WebSocket.WriteWait,
 	}
+
+	if ws.sendBuffer <= 0 {
+		ws.sendBuffer = 64
+	}
+	if ws.slowConsumer != SlowConsumerDrop {
+		ws.slowConsumer = SlowConsumerDisconnect
+	}
+	if ws.pongWait <= 0 {
+		ws.pongWait = 60 * time.Second
+	}
+	if ws.pingInterval <= 0 || ws.pingInterval >= ws.pongWait {
+		ws.pingInterval = ws.pongWait * 9 / 10
+	}
+	if ws.writeWait <= 0 {
+		ws.writeWait = 10 * time.Second
+	}
+
+	replayBuffer := cfg.WebSocket.ReplayBuffer
+	if replayBuffer <= 0 {
+		replayBuffer = 1000
+	}
+
+	// the events table shares sequence numbers between instances, a ring buffer is enough for one
+	switch cfg.WebSocket.Broadcaster {
+	case BroadcasterPostgres:
+		ws.broadcaster = NewPostgresBroadcaster(db, cfg.Database.Host, log)
+		ws.store = NewTableStore(db, replayBuffer)
+	default:
+		ws.broadcaster = NewMemoryBroadcaster()
+		ws.store = NewRingStore(replayBuffer)
+	}
+	return ws
+}
+
+// Start starts receiving the events published by every instance until ctx is done
+func (ws *WebSocketService) Start(ctx context.Context) error {
+	return ws.broadcaster.Start(ctx, ws.deliver)
 }
 
 func (ws *WebSocketService) Upgrade(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
 	return ws.upgrader.Upgrade(w, r, nil)
 }
 
-func (ws *WebSocketService) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+// HandleWebSocket upgrades an authenticated request, the connection is closed when the access token expires
+func (ws *WebSocketService) HandleWebSocket(w http.ResponseWriter, r *http.Request, identity Identity) {
 	// Upgrade the connection to WebSocket
 	conn, err := ws.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		http.Error(w, "Failed to upgrade connection", http.StatusInternalServerError)
 		return
 	}
-	defer conn.Close()
 
-	// Ensure the connections map is initialized
-	if ws.connections == nil {
-		ws.mu.Lock()
-		ws.connections = make(map[*websocket.Conn]bool)
-		ws.mu.Unlock()
+	client := newClient(ws, conn, identity)
+	ws.register(client)
+
+	expiry := time.AfterFunc(time.Until(identity.ExpiresAt), func() {
+		client.closeWith(websocket.ClosePolicyViolation, "token expired")
+	})
+	defer expiry.Stop()
+
+	go client.writePump()
+	client.readPump()
+}
+
+// Publish numbers and retains the event, then sends it to the subscribers connected to every instance
+func (ws *WebSocketService) Publish(ctx context.Context, event Event) error {
+	event, err := ws.store.Append(ctx, event)
+	if errors.Is(err, ErrDuplicateEvent) {
+		// already published, the outbox redelivers events after a partial failure
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return ws.broadcaster.Publish(ctx, event)
+}
+
+// PublishOutbox is the outbox sink of the WebSocket service, the outbox event ID becomes the event ID
+func (ws *WebSocketService) PublishOutbox(ctx context.Context, outboxEvent models.OutboxEvent) error {
+	event := Event{
+		Version:   ProtocolVersion,
+		ID:        outboxEvent.ID,
+		Type:      EventType(outboxEvent.Type),
+		Timestamp: outboxEvent.CreatedAt.UTC(),
+		Payload:   json.RawMessage(outboxEvent.Payload),
+		Owner:     outboxEvent.Owner,
+	}
+	if err := json.Unmarshal(outboxEvent.Topics, &event.Topics); err != nil {
+		return err
 	}
+	return ws.Publish(ctx, event)
+}
 
-	// Add connection to the list
-	ws.mu.Lock()
-	ws.connections[conn] = true
-	ws.mu.Unlock()
+// deliver queues the event for every local client subscribed to one of its topics.
+// It never blocks on a client, a client that does not keep up is handled by the slow consumer policy.
+func (ws *WebSocketService) deliver(event Event) {
+	ws.mu.RLock()
+	clients := make([]*Client, 0, len(ws.clients))
+	for client := range ws.clients {
+		clients = append(clients, client)
+	}
+	ws.mu.RUnlock()
 
-	// Remove connection when done
-	defer func() {
-		ws.mu.Lock()
-		delete(ws.connections, conn)
-		ws.mu.Unlock()
-	}()
-
-	// Example: Keep the connection open
-	for {
-		_, _, err := conn.ReadMessage()
-		if err != nil {
-			break
+	// enqueue can unregister a slow client, so it runs without holding ws.mu
+	for _, client := range clients {
+		if !client.subscribed(event) {
+			continue
 		}
+		client.deliver(event)
 	}
 }
 
-func (ws *WebSocketService) Broadcast(message interface{}) error {
-	fmt.Print("ws.Broadcast called with message: ", ws)
-	fmt.Println(ws)
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
-
-	for conn := range ws.connections {
-		err := conn.WriteJSON(message)
-		if err != nil {
-			// Remove the connection if sending fails
-			conn.Close()
-			delete(ws.connections, conn)
-		}
+// Stats returns a snapshot of the hub metrics
+func (ws *WebSocketService) Stats() Stats {
+	ws.mu.RLock()
+	connected := len(ws.clients)
+	ws.mu.RUnlock()
+
+	return Stats{
+		ConnectedClients:          connected,
+		MessagesSent:              ws.metrics.sent.Load(),
+		MessagesDropped:           ws.metrics.dropped.Load(),
+		SlowConsumersDisconnected: ws.metrics.disconnected.Load(),
 	}
-	return nil
+}
+
+func (ws *WebSocketService) register(client *Client) {
+	ws.mu.Lock()
+	ws.clients[client] = true
+	ws.mu.Unlock()
+}
+
+func (ws *WebSocketService) unregister(client *Client) {
+	ws.mu.Lock()
+	delete(ws.clients, client)
+	ws.mu.Unlock()
 }
@@ -0,0 +1,307 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LeHNam/wao-api/config"
+	"github.com/LeHNam/wao-api/constant"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// newTestHub starts a hub with the memory broadcaster behind an httptest server,
+// every connection is authenticated as a staff user
+func newTestHub(t *testing.T, configure func(cfg *config.Config)) (*WebSocketService, *httptest.Server) {
+	t.Helper()
+	cfg := &config.Config{}
+	if configure != nil {
+		configure(cfg)
+	}
+	ws := NewWebSocketService(cfg, nil, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := ws.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		ws.HandleWebSocket(w, r, Identity{
+			UserID:    uuid.New(),
+			Role:      constant.RoleStaff,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+	})
+	mux.HandleFunc("/ws/metrics", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(ws.Stats())
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return ws, server
+}
+
+// dial connects to the hub and subscribes to every product
+func dial(t *testing.T, server *httptest.Server) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if err := conn.WriteJSON(ClientMessage{Action: ActionSubscribe, Topics: []string{TopicProduct + ":" + TopicWildcard}}); err != nil {
+		t.Fatal(err)
+	}
+	if event := readEvent(t, conn); event.Type != EventSubscribed {
+		t.Fatalf("got %s event, want %s", event.Type, EventSubscribed)
+	}
+	return conn
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn) Event {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var event Event
+	if err := conn.ReadJSON(&event); err != nil {
+		t.Fatal(err)
+	}
+	return event
+}
+
+func fetchStats(t *testing.T, server *httptest.Server) Stats {
+	t.Helper()
+	resp, err := http.Get(server.URL + "/ws/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var stats Stats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		t.Fatal(err)
+	}
+	return stats
+}
+
+// waitFor polls cond until it holds or the test times out
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	ws, server := newTestHub(t, nil)
+	conns := []*websocket.Conn{dial(t, server), dial(t, server), dial(t, server)}
+
+	event := NewEvent(EventProductCreated, map[string]string{"name": "tea"}, ProductTopic(uuid.New()))
+	if err := ws.Publish(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, conn := range conns {
+		got := readEvent(t, conn)
+		if got.ID != event.ID || got.Type != EventProductCreated || got.Seq == 0 {
+			t.Errorf("client %d got %+v, want event %s", i, got, event.ID)
+		}
+	}
+}
+
+func TestSlowConsumerDisconnect(t *testing.T) {
+	ws, server := newTestHub(t, func(cfg *config.Config) {
+		cfg.WebSocket.SendBuffer = 1
+		cfg.WebSocket.SlowConsumerPolicy = string(SlowConsumerDisconnect)
+	})
+	slow := dial(t, server)
+
+	// the client stops reading, its queue fills up once the socket buffers do
+	for i := 0; ws.Stats().SlowConsumersDisconnected == 0; i++ {
+		if i == 100000 {
+			t.Fatal("slow consumer was never disconnected")
+		}
+		_ = ws.Publish(context.Background(), NewEvent(EventProductCreated, strings.Repeat("x", 1024), ProductTopic(uuid.New())))
+	}
+
+	_ = slow.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if _, _, err := slow.ReadMessage(); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseTryAgainLater) {
+				t.Errorf("read error = %v, want close %d", err, websocket.CloseTryAgainLater)
+			}
+			break
+		}
+	}
+	waitFor(t, "the slow client to be unregistered", func() bool {
+		return ws.Stats().ConnectedClients == 0
+	})
+}
+
+func TestSlowConsumerDrop(t *testing.T) {
+	ws, server := newTestHub(t, func(cfg *config.Config) {
+		cfg.WebSocket.SendBuffer = 1
+		cfg.WebSocket.SlowConsumerPolicy = string(SlowConsumerDrop)
+	})
+	slow := dial(t, server)
+
+	for i := 0; ws.Stats().MessagesDropped == 0; i++ {
+		if i == 100000 {
+			t.Fatal("no message was dropped")
+		}
+		_ = ws.Publish(context.Background(), NewEvent(EventProductCreated, strings.Repeat("x", 1024), ProductTopic(uuid.New())))
+	}
+
+	stats := ws.Stats()
+	if stats.ConnectedClients != 1 || stats.SlowConsumersDisconnected != 0 {
+		t.Errorf("stats = %+v, want the slow client to stay connected", stats)
+	}
+	// the connection is still usable once the client catches up
+	if event := readEvent(t, slow); event.Type != EventProductCreated {
+		t.Errorf("got %s event, want %s", event.Type, EventProductCreated)
+	}
+}
+
+func TestPongTimeout(t *testing.T) {
+	ws, server := newTestHub(t, func(cfg *config.Config) {
+		cfg.WebSocket.PingInterval = 50 * time.Millisecond
+		cfg.WebSocket.PongWait = 200 * time.Millisecond
+	})
+
+	alive := dial(t, server)
+	dead := dial(t, server)
+	// a client that never answers pings
+	dead.SetPingHandler(func(string) error { return nil })
+
+	// the default ping handler answers with a pong while the client reads
+	aliveErr := make(chan error, 1)
+	go func() {
+		_ = alive.SetReadDeadline(time.Time{})
+		_, _, err := alive.ReadMessage()
+		aliveErr <- err
+	}()
+
+	_ = dead.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, _, err := dead.ReadMessage(); err == nil {
+		t.Fatal("connection without pongs was not closed")
+	}
+	waitFor(t, "the dead client to be unregistered", func() bool {
+		return ws.Stats().ConnectedClients == 1
+	})
+
+	// well past pong_wait, the answering client is still connected
+	select {
+	case err := <-aliveErr:
+		t.Fatalf("answering client was closed: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+	if connected := ws.Stats().ConnectedClients; connected != 1 {
+		t.Errorf("connected clients = %d, want 1", connected)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	ws, server := newTestHub(t, nil)
+	first := dial(t, server)
+	second := dial(t, server)
+
+	if err := ws.Publish(context.Background(), NewEvent(EventProductCreated, nil, ProductTopic(uuid.New()))); err != nil {
+		t.Fatal(err)
+	}
+	readEvent(t, first)
+	readEvent(t, second)
+
+	// two subscription acks and the event for each client
+	waitFor(t, "the sent counter", func() bool {
+		return fetchStats(t, server).MessagesSent == 4
+	})
+	stats := fetchStats(t, server)
+	want := Stats{ConnectedClients: 2, MessagesSent: 4}
+	if stats != want {
+		t.Errorf("metrics = %+v, want %+v", stats, want)
+	}
+
+	first.Close()
+	waitFor(t, "the closed client to be unregistered", func() bool {
+		return fetchStats(t, server).ConnectedClients == 1
+	})
+}
+
+func TestResumeLargerThanSendBuffer(t *testing.T) {
+	ws, server := newTestHub(t, func(cfg *config.Config) {
+		cfg.WebSocket.SendBuffer = 4
+		cfg.WebSocket.SlowConsumerPolicy = string(SlowConsumerDisconnect)
+	})
+
+	// many more missed events than fit in the send queue
+	const missed = 100
+	for i := 0; i < missed; i++ {
+		if err := ws.Publish(context.Background(), NewEvent(EventProductCreated, nil, ProductTopic(uuid.New()))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	resumeFrom := int64(0)
+	err = conn.WriteJSON(ClientMessage{Action: ActionSubscribe, Topics: []string{TopicProduct + ":" + TopicWildcard}, ResumeFrom: &resumeFrom})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event := readEvent(t, conn); event.Type != EventSubscribed {
+		t.Fatalf("got %s event, want %s", event.Type, EventSubscribed)
+	}
+
+	for seq := int64(1); seq <= missed; seq++ {
+		if event := readEvent(t, conn); event.Seq != seq {
+			t.Fatalf("got event %d (%s), want %d", event.Seq, event.Type, seq)
+		}
+	}
+	if stats := ws.Stats(); stats.SlowConsumersDisconnected != 0 || stats.MessagesDropped != 0 {
+		t.Errorf("stats = %+v, the resuming client should not count as a slow consumer", stats)
+	}
+}
+
+func TestRingStoreSinceAheadOfSequence(t *testing.T) {
+	store := NewRingStore(10)
+	for i := 0; i < 3; i++ {
+		if _, err := store.Append(context.Background(), NewEvent(EventProductCreated, nil, ProductTopic(uuid.New()))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		seq    int64
+		events int
+		ok     bool
+	}{
+		{0, 3, true},
+		{2, 1, true},
+		{3, 0, true},
+		// a client resuming from before a restart of the process
+		{500, 0, false},
+	}
+	for _, tt := range tests {
+		events, ok, err := store.Since(context.Background(), tt.seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != tt.events || ok != tt.ok {
+			t.Errorf("Since(%d) = %d events, ok %v, want %d events, ok %v", tt.seq, len(events), ok, tt.events, tt.ok)
+		}
+	}
+}
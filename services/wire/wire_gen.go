@@ -28,7 +28,7 @@ func InitializeServer() (*server.Server, error) {
 	}
 	logger := log.NewZapLogger(configConfig)
 	serviceContext := context.NewServiceContext(configConfig, db, logger)
-	webSocketService := websocket.NewWebSocketService()
+	webSocketService := websocket.NewWebSocketService(configConfig, db, logger)
 	serverServer := server.NewServer(serviceContext, webSocketService)
 	return serverServer, nil
 }